@@ -0,0 +1,36 @@
+package gametheory
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrImperfectRecall is matched by every *ImperfectRecallError.
+	ErrImperfectRecall = errors.New("imperfect recall")
+
+	// ErrReservedName means a move used the name reserved for the empty sequence.
+	ErrReservedName = errors.New("use of reserved name for empty sequence")
+
+	// ErrPlayerCount means a sequence form LCP was asked for a game that
+	// does not have exactly two players.
+	ErrPlayerCount = errors.New("sequence form LCP must have two and only two players")
+)
+
+// ImperfectRecallError tells which information set of which player can be
+// reached by more than one of the player's own sequences.
+type ImperfectRecallError struct {
+	Player   string
+	Iset     string
+	Seq      string // own sequence that just reached the iset
+	Expected string // own sequence that reached it before
+}
+
+func (e *ImperfectRecallError) Error() string {
+	return fmt.Sprintf("imperfect recall: player %s reaches information set %s by %s and by %s", e.Player, e.Iset, e.Expected, e.Seq)
+}
+
+// Is lets errors.Is(err, ErrImperfectRecall) match.
+func (e *ImperfectRecallError) Is(target error) bool {
+	return target == ErrImperfectRecall
+}
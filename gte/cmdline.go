@@ -2,40 +2,111 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/megesdal/gametheory/lemke"
 )
 
 var inputFile = flag.Bool("f", false, "input is a file name and not a matrix")
+var exportFormat = flag.String("export", "", "write the LCP as json, text or gams instead of solving it")
 
 func main() {
-	fmt.Println("Hello, 世界")
-
 	flag.Parse()
-	fmt.Println(flag.Args())
 
-	who := flag.Arg(0)
-	fmt.Printf("Hello, %s\n", who)
+	// stdout is kept for the solution or the exported LCP
+	if err := run(flag.Arg(0), flag.Arg(1)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run carries out the command  who  on  what, which is a matrix or the
+// name of a file holding one.
+func run(who string, what string) error {
 
 	if who == "lemke" {
-		fmt.Println("I need an M, q, and d")
+		if what == "" {
+			return fmt.Errorf("I need an M, q, and d")
+		}
+
+		var input io.Reader = strings.NewReader(what)
+		if *inputFile {
+			file, err := os.Open(what)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			input = file
+		}
+
+		return runLemke(input, os.Stdout, *exportFormat)
 	} else if who == "nash" {
 		fmt.Println("I need a payment matrix")
 		//tableau := lemke.NewLCP(5)
 		//fmt.Println(tableau)
-		what := flag.Arg(1)
 		if *inputFile {
 			fmt.Println("Looking for file", what)
 		} else {
 			var payMatrix [][][]float64
 			err := json.Unmarshal([]byte(what), &payMatrix)
 			if err != nil {
-				fmt.Println(err)
-				return
+				return err
 			}
 
 			// TODO: verify input
 			fmt.Println(payMatrix)
 		}
 	}
+	return nil
+}
+
+// runLemke reads an LCP in JSON or text format and either solves it or
+// writes it back out in the given format.
+func runLemke(input io.Reader, output io.Writer, format string) error {
+
+	lcp, d, err := lemke.ReadLCP(input)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		return lemke.WriteJSON(output, lcp, d)
+	case "text":
+		return lemke.WriteText(output, lcp, d)
+	case "gams":
+		return lemke.WriteGAMS(output, lcp)
+	case "":
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+
+	if d == nil {
+		d = make([]*big.Rat, lcp.N())
+		for i := range d {
+			d[i] = big.NewRat(1, 1)
+		}
+	}
+
+	z, err := lemke.Solve(lcp, d)
+	if errors.Is(err, lemke.ErrTrivialSolution) {
+		// q >= 0: z = 0 solves it
+		z = make([]*big.Rat, lcp.N())
+		for i := range z {
+			z[i] = new(big.Rat)
+		}
+	} else if err != nil {
+		return err
+	}
+
+	for i, value := range z {
+		fmt.Fprintf(output, "z%d = %s\n", i+1, value.RatString())
+	}
+	return nil
 }
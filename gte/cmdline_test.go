@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/megesdal/gametheory/lemke"
+	"github.com/stretchr/testify/assert"
+)
+
+const textLCP = "2  2 1  1 3  -1 -1  1 1"
+
+func TestRunLemkeSolves(t *testing.T) {
+
+	var out bytes.Buffer
+	assert.Nil(t, runLemke(strings.NewReader(textLCP), &out, ""))
+	assert.Equal(t, "z1 = 2/5\nz2 = 1/5\n", out.String())
+
+	// without d the covering vector is all ones
+	out.Reset()
+	assert.Nil(t, runLemke(strings.NewReader("1  2  -1"), &out, ""))
+	assert.Equal(t, "z1 = 1/2\n", out.String())
+
+	// q >= 0 is solved by z = 0
+	out.Reset()
+	assert.Nil(t, runLemke(strings.NewReader("2  2 1  1 3  1 0"), &out, ""))
+	assert.Equal(t, "z1 = 0\nz2 = 0\n", out.String())
+}
+
+func TestRunLemkeExports(t *testing.T) {
+
+	for _, format := range []string{"json", "text"} {
+		var out bytes.Buffer
+		assert.Nil(t, runLemke(strings.NewReader(textLCP), &out, format), format)
+
+		// nothing but the LCP on the output
+		lcp, d, err := lemke.ReadLCP(&out)
+		assert.Nil(t, err, format)
+		assert.Equal(t, 2, lcp.N())
+		assert.Equal(t, 2, len(d))
+	}
+
+	var out bytes.Buffer
+	assert.Nil(t, runLemke(strings.NewReader(textLCP), &out, "gams"))
+	assert.True(t, strings.Contains(out.String(), "using mcp"))
+}
+
+func TestRunLemkeErrors(t *testing.T) {
+
+	var out bytes.Buffer
+	err := runLemke(strings.NewReader(textLCP), &out, "xml")
+	assert.NotNil(t, err)
+
+	err = runLemke(strings.NewReader("2 1 2"), &out, "")
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+
+	err = run("lemke", "")
+	assert.NotNil(t, err)
+
+	err = runLemke(strings.NewReader("1  -1  -1  1"), &out, "")
+	assert.True(t, errors.Is(err, lemke.ErrRayTermination))
+	assert.Equal(t, "", out.String())
+}
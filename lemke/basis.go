@@ -0,0 +1,176 @@
+package lemke
+
+import (
+	"fmt"
+	"math/big"
+)
+
+/*
+ * factorize
+ * ================================================================
+ * pivot the tableau until exactly the variables in  basis  are basic.
+ * Variables already basic and wanted stay put, every other wanted
+ * variable replaces some unwanted basic variable with a nonzero entry
+ * in its column.  If there is none the wanted columns are dependent.
+ * The rhs must already have its correct sign.  pivoted, if not nil,
+ * is told about every pivot.
+ * @return the number of pivots performed
+ */
+func (A *tableau) factorize(basis []Variable, pivoted func(enter *tableauVariable, leave *tableauVariable, row int, col int)) (int, error) {
+
+	wanted, err := A.vars.wanted(basis)
+	if err != nil {
+		return 0, err
+	}
+
+	pivots := 0
+	for _, v := range basis {
+
+		enter := A.vars.lookupVariable(v)
+		if enter.isBasic() {
+			continue
+		}
+
+		col := enter.col()
+		leaveRow := -1
+		for i := 0; i < A.nrows; i++ {
+			if !wanted[A.vars.fromRow(i).idx] && A.sign(i, col) != 0 {
+				leaveRow = i
+				break
+			}
+		}
+
+		if leaveRow < 0 {
+			return pivots, fmt.Errorf("%w: singular, %v depends on the other basic columns", ErrBadBasis, v)
+		}
+
+		leave := A.vars.fromRow(leaveRow)
+		row, col, err := A.pivot(leave, enter)
+		if err != nil {
+			return pivots, err
+		}
+		pivots++
+		if pivoted != nil {
+			pivoted(enter, leave, row, col)
+		}
+	}
+
+	return pivots, nil
+}
+
+// wanted is the set of indices of the variables of  basis, an error if
+// they cannot be one.
+func (vars *tableauVariables) wanted(basis []Variable) (map[int]bool, error) {
+
+	if len(basis) != vars.n {
+		return nil, fmt.Errorf("%w: %d variables for %d rows", ErrBadBasis, len(basis), vars.n)
+	}
+
+	wanted := make(map[int]bool, len(basis))
+	for _, v := range basis {
+		tvar := vars.lookupVariable(v)
+		if tvar == nil {
+			return nil, fmt.Errorf("%w: unknown variable %v", ErrBadBasis, v)
+		}
+		if wanted[tvar.idx] {
+			return nil, fmt.Errorf("%w: %v given twice", ErrBadBasis, v)
+		}
+		wanted[tvar.idx] = true
+	}
+	return wanted, nil
+}
+
+// feasible reports whether every basic variable is non-negative.
+func (A *tableau) feasible() bool {
+	for i := 0; i < A.nrows; i++ {
+		if A.sign(i, A.rhsCol())*A.det.Sign() < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// complementary reports whether z0 is cobasic and exactly one of
+// z(i), w(i) is basic for every i.
+func (vars *tableauVariables) complementary() bool {
+
+	if vars.z(0).isBasic() {
+		return false
+	}
+
+	for i := 1; i <= vars.n; i++ {
+		if vars.z(i).isBasic() == vars.w(i).isBasic() {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+ * startWarm
+ * ================================================================
+ * factorize the tableau to the complementary basis  opts.WarmStart,
+ * telling the Observer about these pivots as about Lemke's.  If the
+ * basis is feasible we are done.  Otherwise it plays the part of the
+ * all w basis of a cold start, with  d  read in the basis: the z0 col
+ * is replaced by  -det d, scaled like d, so that z0 raises the basic
+ * variable of pair i, be it z(i) or w(i), by  d(i), and z0 enters
+ * where the lexmin ratio of basic variable to  d  is.  With the all w
+ * basis that is exactly the cold start.
+ */
+func startWarm(tableau *tableau, scaleFactors []*big.Int, d []*big.Rat, opts *Options) (*Solver, error) {
+
+	tableau.negateCol(tableau.rhsCol())
+
+	T := scaledTableau{tableau, scaleFactors}
+	count := 0
+	factorPivots, err := tableau.factorize(opts.WarmStart, func(enter *tableauVariable, leave *tableauVariable, row int, col int) {
+		count++
+		opts.notify(T, count, enter, leave, row, col, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !tableau.vars.complementary() {
+		return nil, fmt.Errorf("%w: warm start basis %v is not complementary", ErrBadBasis, opts.WarmStart)
+	}
+
+	if tableau.feasible() {
+		return solvedSolver(T, opts, factorPivots), nil
+	}
+
+	if factorPivots == 0 {
+		// still the all w basis, nothing to gain
+		tableau.negateCol(tableau.rhsCol())
+		return startLemke(tableau, scaleFactors, opts)
+	}
+
+	// det > 0 after factorizing, so the ratio test sees z0 decreasing
+	// the basic variables as it does for d in the first step of a cold
+	// start
+	enter := tableau.vars.z(0)
+	col := enter.col()
+	dcol := scaleColumn(tableau.nrows, func(i int) *big.Rat { return d[i] }, scaleFactors[0])
+	for i := 0; i < tableau.nrows; i++ {
+		pair := tableau.vars.fromRow(i).variable().Index() - 1
+		if d[pair].Sign() == 0 && tableau.sign(i, tableau.rhsCol()) < 0 {
+			return nil, fmt.Errorf("%w: d(%d) is zero but %v is negative in the warm start basis", ErrBadCoveringVector, pair+1, tableau.vars.fromRow(i))
+		}
+		tableau.set(i, col, new(big.Int).Mul(tableau.det, dcol[pair]))
+	}
+
+	rule, _ := opts.pivotRule(tableau.vars.n)
+	leave, z0leave, ties, err := minratio(tableau, enter, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	tableau.negateCol(col)
+
+	s := newSolver(T, opts, enter, leave, z0leave, ties)
+	s.pivots = factorPivots
+	s.factorPivots = factorPivots
+	s.tieCounts = make([]int, factorPivots)
+	return s, nil
+}
@@ -0,0 +1,149 @@
+package lemke
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFactorize(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{-1, -1}))
+	A, scaleFactors, err := createTableau(lcp, ints2rats([]int{2, 1}), AutoStorage)
+	assert.Nil(t, err)
+	A.negateCol(A.rhsCol())
+
+	pivots, err := A.factorize([]Variable{Z(2), Z(1)}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pivots)
+	assert.Equal(t, true, A.vars.complementary())
+	assert.Equal(t, true, A.feasible())
+
+	z := solution(A, scaleFactors)
+	assert.Equal(t, "2/5", z[0].RatString())
+	assert.Equal(t, "1/5", z[1].RatString())
+
+	pivots, err = A.factorize([]Variable{W(1), Z(2)}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, pivots)
+	assert.Equal(t, false, A.feasible(), "w1 = -2/3")
+}
+
+func TestFactorizeBadBasis(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{1, 1, 1, 1}), ints2rats([]int{-1, -1}))
+	A, _, err := createTableau(lcp, ints2rats([]int{1, 1}), AutoStorage)
+	assert.Nil(t, err)
+	A.negateCol(A.rhsCol())
+
+	_, err = A.factorize([]Variable{Z(1), Z(2)}, nil)
+	assert.True(t, errors.Is(err, ErrBadBasis), "z1 and z2 have the same column")
+
+	_, err = A.factorize([]Variable{Z(1)}, nil)
+	assert.True(t, errors.Is(err, ErrBadBasis))
+
+	_, err = A.factorize([]Variable{Z(1), Z(1)}, nil)
+	assert.True(t, errors.Is(err, ErrBadBasis))
+
+	_, err = A.factorize([]Variable{Z(1), W(3)}, nil)
+	assert.True(t, errors.Is(err, ErrBadBasis))
+}
+
+func TestWarmStartAfterPayoffEdit(t *testing.T) {
+
+	for seed := int64(1); seed <= 5; seed++ {
+		lcp, d := randomTestLCP(t, 20, seed)
+
+		first, err := SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+
+		// same basis is still optimal, only the pivots to factorize it
+		again, err := SolveResult(lcp, d, &Options{WarmStart: first.Basis})
+		assert.Nil(t, err)
+		assert.True(t, again.Pivots > 0)
+		assert.Equal(t, again.Pivots, again.FactorPivots)
+		assert.Equal(t, again.Pivots, len(again.Ties))
+		assert.Equal(t, first.Z, again.Z)
+
+		// a small edit of q moves the solution a little
+		for i := 0; i < lcp.n; i += 3 {
+			lcp.q[i] = new(big.Rat).Sub(lcp.q[i], big.NewRat(int64(2*i+1), 1))
+		}
+
+		cold, err := SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+
+		warm, err := SolveResult(lcp, d, &Options{WarmStart: first.Basis})
+		assert.Nil(t, err)
+		assert.Equal(t, Solved, warm.Status)
+		assert.True(t, warm.FactorPivots > 0)
+		assert.True(t, warm.Pivots-warm.FactorPivots <= cold.Pivots, "warm %d cold %d", warm.Pivots, cold.Pivots)
+		for i := range cold.Z {
+			assert.Equal(t, 0, cold.Z[i].Cmp(warm.Z[i]), "z%d", i+1)
+		}
+	}
+}
+
+func TestWarmStartNeedsComplementaryBasis(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{-1, -1}))
+
+	_, err := SolveResult(lcp, ints2rats([]int{2, 1}), &Options{WarmStart: []Variable{Z(1), W(1)}})
+	assert.True(t, errors.Is(err, ErrBadBasis))
+
+	_, err = SolveResult(lcp, ints2rats([]int{2, 1}), &Options{WarmStart: []Variable{Z(0), Z(1)}})
+	assert.True(t, errors.Is(err, ErrBadBasis))
+}
+
+func TestWarmStartObserver(t *testing.T) {
+
+	lcp, d := randomTestLCP(t, 10, 2)
+	first, err := SolveResult(lcp, d, nil)
+	assert.Nil(t, err)
+	lcp.q[1] = new(big.Rat).Sub(lcp.q[1], big.NewRat(30, 1))
+
+	var steps []*PivotStep
+	warm, err := SolveResult(lcp, d, &Options{
+		WarmStart: first.Basis,
+		Observer: ObserverFunc(func(step *PivotStep) {
+			steps = append(steps, step)
+		}),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, warm.Pivots, len(steps))
+	for i, step := range steps {
+		assert.Equal(t, i+1, step.Count)
+	}
+	assert.Equal(t, Z(0), steps[warm.FactorPivots].Enter)
+}
+
+func TestWarmStartCoveringVector(t *testing.T) {
+
+	// with the warm basis {z1, w2}:  z1 = -1  and  w2 = -2
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{2, -1}))
+	basis := []Variable{Z(1), W(2)}
+
+	leaving := func(d []*big.Rat) Variable {
+		var leave Variable
+		res, err := SolveResult(lcp, d, &Options{
+			WarmStart: basis,
+			Observer: ObserverFunc(func(step *PivotStep) {
+				if step.Enter == Z(0) {
+					leave = step.Leave
+				}
+			}),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, Solved, res.Status)
+		return leave
+	}
+
+	// z0 enters where basic variable / d is least
+	assert.Equal(t, W(2), leaving(ints2rats([]int{1, 1})))
+	assert.Equal(t, Z(1), leaving(ints2rats([]int{1, 4})))
+
+	_, err := SolveResult(lcp, ints2rats([]int{0, 1}), &Options{WarmStart: basis})
+	assert.True(t, errors.Is(err, ErrBadCoveringVector))
+}
@@ -0,0 +1,112 @@
+package lemke
+
+import (
+	"context"
+	"math/big"
+	"sync"
+)
+
+// Batch is what SolveBatch found for a list of covering vectors.
+type Batch struct {
+	// Results and Errors are those of SolveResult for each covering
+	// vector, in the order given.
+	Results []*Result
+	Errors  []error
+
+	// Solutions are the distinct z among the Solved results, in the
+	// order first found, and SolutionOf is the index into Solutions for
+	// each covering vector, -1 if it did not solve the LCP.
+	Solutions  [][]*big.Rat
+	SolutionOf []int
+}
+
+// SolveBatch runs Lemke's algorithm on one LCP for each of the covering
+// vectors ds, e.g. for several random priors of a game.  Scale factors
+// and the columns of M and q of the tableau are only computed once.
+// Options.Concurrent solves several vectors at once; the results do not
+// depend on it.
+func SolveBatch(lcp *LCP, ds [][]*big.Rat, opts *Options) (*Batch, error) {
+	return SolveBatchContext(context.Background(), lcp, ds, opts)
+}
+
+// SolveBatchContext is SolveBatch but every run checks ctx before each
+// pivot, so once ctx is done the remaining ones end with a
+// *CanceledError in Batch.Errors.  The error returned is only for
+// options that cannot work for any covering vector.
+func SolveBatchContext(ctx context.Context, lcp *LCP, ds [][]*big.Rat, opts *Options) (*Batch, error) {
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	if _, err := opts.pivotRule(lcp.n); err != nil {
+		return nil, err
+	}
+
+	// only a plain exact start has a tableau to share
+	solve := func(d []*big.Rat) (*Result, error) {
+		return SolveResultContext(ctx, lcp, d, opts)
+	}
+	if opts.Method == Lemke && opts.BlockSize == 0 && (!opts.FloatPivoting || opts.WarmStart != nil) {
+		template := newTableauTemplate(lcp, opts.Storage)
+		once := func(d []*big.Rat, opts *Options) (*Result, error) {
+			if err := checkInputs(lcp.q, d); err != nil {
+				return nil, err
+			}
+			tableau, scaleFactors := template.fill(d)
+			s, err := startTableau(tableau, scaleFactors, d, opts)
+			if err != nil {
+				return nil, err
+			}
+			return s.solve(ctx)
+		}
+		solve = func(d []*big.Rat) (*Result, error) {
+			return withRestarts(lcp.n, d, opts, once)
+		}
+	}
+
+	batch := &Batch{
+		Results:    make([]*Result, len(ds)),
+		Errors:     make([]error, len(ds)),
+		SolutionOf: make([]int, len(ds)),
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	workers := opts.Concurrent
+	if workers < 1 {
+		workers = 1
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range next {
+				batch.Results[k], batch.Errors[k] = solve(ds[k])
+			}
+		}()
+	}
+	for k := range ds {
+		next <- k
+	}
+	close(next)
+	wg.Wait()
+
+	seen := make(map[string]int)
+	for k, res := range batch.Results {
+		batch.SolutionOf[k] = -1
+		if res == nil || res.Status != Solved {
+			continue
+		}
+
+		key := solutionKey(res.Z)
+		index, ok := seen[key]
+		if !ok {
+			index = len(batch.Solutions)
+			seen[key] = index
+			batch.Solutions = append(batch.Solutions, res.Z)
+		}
+		batch.SolutionOf[k] = index
+	}
+	return batch, nil
+}
@@ -0,0 +1,86 @@
+package lemke
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomCoveringVectors(n int, count int, seed int64) [][]*big.Rat {
+	r := rand.New(rand.NewSource(seed))
+	ds := make([][]*big.Rat, count)
+	for k := range ds {
+		ds[k] = make([]*big.Rat, n)
+		for i := range ds[k] {
+			ds[k][i] = big.NewRat(int64(r.Intn(9)+1), int64(r.Intn(4)+1))
+		}
+	}
+	return ds
+}
+
+func TestSolveBatchMatchesSolveResult(t *testing.T) {
+
+	lcp, _ := bimatrixTestLCP(t, 3, 5, 2)
+	ds := randomCoveringVectors(lcp.n, 12, 1)
+
+	for _, concurrent := range []int{0, 4} {
+		batch, err := SolveBatch(lcp, ds, &Options{Concurrent: concurrent})
+		assert.Nil(t, err)
+
+		for k, d := range ds {
+			expected, err := SolveResult(lcp, d, nil)
+			assert.Nil(t, err)
+			assert.Nil(t, batch.Errors[k])
+			assert.Equal(t, solutionKey(expected.Z), solutionKey(batch.Results[k].Z))
+			assert.Equal(t, expected.Pivots, batch.Results[k].Pivots)
+
+			index := batch.SolutionOf[k]
+			assert.Equal(t, solutionKey(expected.Z), solutionKey(batch.Solutions[index]))
+		}
+
+		// solutions are distinct
+		keys := make(map[string]bool)
+		for _, z := range batch.Solutions {
+			keys[solutionKey(z)] = true
+		}
+		assert.Equal(t, len(batch.Solutions), len(keys))
+	}
+}
+
+func TestSolveBatchPerVectorErrors(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{-1, -1}))
+	ds := [][]*big.Rat{
+		ints2rats([]int{2, 1}),
+		ints2rats([]int{-1, 1}),
+		ints2rats([]int{1}),
+		ints2rats([]int{1, 2}),
+	}
+
+	batch, err := SolveBatch(lcp, ds, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, batch.Errors[0])
+	assert.True(t, errors.Is(batch.Errors[1], ErrBadCoveringVector))
+	assert.True(t, errors.Is(batch.Errors[2], ErrDimension))
+	assert.Nil(t, batch.Errors[3])
+	assert.Equal(t, []int{0, -1, -1, 0}, batch.SolutionOf)
+	assert.Equal(t, 1, len(batch.Solutions))
+}
+
+func TestSolveBatchCanceled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	lcp, _ := bimatrixTestLCP(t, 3, 5, 2)
+	batch, err := SolveBatchContext(ctx, lcp, randomCoveringVectors(lcp.n, 3, 1), &Options{Concurrent: 2})
+	assert.Nil(t, err)
+	for k := range batch.Errors {
+		assert.True(t, errors.Is(batch.Errors[k], context.Canceled))
+		assert.Equal(t, -1, batch.SolutionOf[k])
+	}
+}
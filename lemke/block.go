@@ -0,0 +1,400 @@
+package lemke
+
+import (
+	"fmt"
+	"math/big"
+)
+
+/*
+ * blockTableau
+ * ================================================================
+ * Lemke's algorithm for  M = [ 0 P ; Q 0 ]  as in the LCP of a bimatrix
+ * game.  The rows of the first diagonal block only involve  w1, z2  and
+ * z0, those of the second only  w2, z1  and z0, so each block gets its
+ * own integer tableau of size  k x (n-k+2)  instead of one  n x (n+2)
+ * tableau, with the columns of the full tableau scaled the same way.
+ * They are coupled through z0 alone: once z0 is basic in one block it
+ * stays a column of the other, and the full tableau there is that
+ * column times the row of z0, a rank one term.
+ *
+ * Up to a positive factor per row, the full tableau is
+ *     D[r][x] dO - D[r][z0] O[r0][x]
+ * for a row  r  of block D whose other block O has z0 basic in row  r0,
+ * and just  D[r][x]  otherwise.  Signs and ratio tests do not see such
+ * factors, so lexminratio and the pivot rules take the very same steps
+ * as on the integer tableau, in integers.
+ */
+type blockTableau struct {
+	vars         *tableauVariables
+	blocks       [2]*blockDictionary
+	scaleFactors []*big.Int // of the columns of the full tableau
+	detSign      int        // -1 until the first pivot, as for the integer tableau
+	workers      int
+
+	entries [4]*big.Int // scratch for ratio tests
+	tmp     *big.Int
+}
+
+/*
+ * blockDictionary
+ * ================================================================
+ * the integer tableau of one block: a row for each variable basic in
+ * it and a column for each other variable of the block, z0 included
+ * even if it is basic in the other block, and the rhs last.
+ */
+type blockDictionary struct {
+	store storage
+	det   *big.Int
+	ncols int
+	row   []int // of each variable basic here, by idx, -1 for the others
+	col   []int // of each cobasic variable of the block, by idx, -1 for the others
+}
+
+func newBlockTableau(lcp *LCP, d []*big.Rat, k int, kind StorageKind) (*blockTableau, error) {
+
+	n := lcp.n
+	if k < 1 || k >= n {
+		return nil, fmt.Errorf("%w: block size %d for an LCP of size %d", ErrDimension, k, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (i < k) == (j < k) && lcp.M(i, j).Sign() != 0 {
+				return nil, fmt.Errorf("%w: M(%d,%d) is in a diagonal block but not zero", ErrMatrixClass, i+1, j+1)
+			}
+		}
+	}
+
+	if kind == AutoStorage {
+		kind = Int64Storage
+	}
+
+	B := &blockTableau{
+		vars:         newTableauVariables(n),
+		scaleFactors: make([]*big.Int, n+2),
+		detSign:      -1,
+		tmp:          new(big.Int),
+	}
+	for i := range B.entries {
+		B.entries[i] = new(big.Int)
+	}
+
+	// block 0 has rows 1..k, block 1 rows k+1..n
+	bounds := [3]int{0, k, n}
+	for b := 0; b < 2; b++ {
+		first, last := bounds[b], bounds[b+1]
+		other := 1 - b
+
+		D := &blockDictionary{
+			det:   big.NewInt(-1),
+			ncols: bounds[other+1] - bounds[other] + 2,
+			row:   make([]int, 2*n+1),
+			col:   make([]int, 2*n+1),
+		}
+		for idx := range D.row {
+			D.row[idx], D.col[idx] = -1, -1
+		}
+		for i := first; i < last; i++ {
+			D.row[B.vars.w(i+1).idx] = i - first
+		}
+		D.col[B.vars.z(0).idx] = 0
+		for j := bounds[other]; j < bounds[other+1]; j++ {
+			D.col[B.vars.z(j+1).idx] = j - bounds[other] + 1
+		}
+
+		switch kind {
+		case SparseStorage:
+			D.store = newSparseStorage(last-first, D.ncols)
+		case Int64Storage:
+			D.store = newInt64Storage(last-first, D.ncols)
+		default:
+			D.store = newDenseStorage(last-first, D.ncols)
+		}
+		B.blocks[b] = D
+	}
+
+	/* cols of the full tableau as in tableauTemplate, split by rows */
+	for j := 0; j <= n+1; j++ {
+		fnVec := func(i int) *big.Rat {
+			switch j {
+			case 0:
+				return d[i]
+			case n + 1:
+				return lcp.q[i]
+			}
+			return lcp.M(i, j-1)
+		}
+
+		B.scaleFactors[j] = computeScaleFactor(n, fnVec)
+		for i, value := range scaleColumn(n, fnVec, B.scaleFactors[j]) {
+			b := 0
+			if i >= k {
+				b = 1
+			}
+			D := B.blocks[b]
+			c := D.ncols - 1
+			if j <= n {
+				c = D.col[j] // z(j) has idx j
+			}
+			if c >= 0 {
+				D.store.set(i-bounds[b], c, value)
+			}
+		}
+	}
+	return B, nil
+}
+
+// pivot exchanges  leave, basic in this block, for  enter, a column of
+// it, by the integer pivoting step of the storage.
+func (D *blockDictionary) pivot(leave *tableauVariable, enter *tableauVariable, workers int) {
+	r, c := D.row[leave.idx], D.col[enter.idx]
+	D.det = D.store.pivot(r, c, D.det, workers)
+	D.row[enter.idx], D.col[leave.idx] = r, c
+	D.row[leave.idx], D.col[enter.idx] = -1, -1
+}
+
+// home is the block in which the basic variable v is basic, and the
+// other one.
+func (B *blockTableau) home(v *tableauVariable) (*blockDictionary, *blockDictionary) {
+	if B.blocks[0].row[v.idx] >= 0 {
+		return B.blocks[0], B.blocks[1]
+	}
+	return B.blocks[1], B.blocks[0]
+}
+
+// blockCol is the column of  col  of the full tableau in D, -1 if the
+// variable there is not one of D.
+func (B *blockTableau) blockCol(D *blockDictionary, col int) int {
+	if col == B.rhsCol() {
+		return D.ncols - 1
+	}
+	return D.col[B.vars.fromCol(col).idx]
+}
+
+/*
+ * rowEntry sets  e  to the full tableau at  row, col  up to the positive
+ * factor of the row, and returns the sign that factor lacks: that of
+ * det  of the block of the row and of the full determinant.
+ */
+func (B *blockTableau) rowEntry(e *big.Int, row int, col int) (*big.Int, int) {
+
+	D, O := B.home(B.vars.fromRow(row))
+	r := D.row[B.vars.fromRow(row).idx]
+	sign := D.det.Sign() * B.detSign
+
+	e.SetInt64(0)
+	if c := B.blockCol(D, col); c >= 0 {
+		e.Set(D.store.entry(r, c))
+	}
+
+	r0 := O.row[B.vars.z(0).idx]
+	if r0 < 0 {
+		return e, sign
+	}
+	e.Mul(e, O.det)
+	if c := B.blockCol(O, col); c >= 0 {
+		B.tmp.Mul(D.store.entry(r, D.col[B.vars.z(0).idx]), O.store.entry(r0, c))
+		e.Sub(e, B.tmp)
+	}
+	return e, sign
+}
+
+// fullRatio is  A[row][col] / det  of the full tableau.
+func (B *blockTableau) fullRatio(row int, col int) *big.Rat {
+
+	D, O := B.home(B.vars.fromRow(row))
+	den := new(big.Int).Set(D.det)
+	if O.row[B.vars.z(0).idx] >= 0 {
+		den.Mul(den, O.det)
+	}
+	e, _ := B.rowEntry(new(big.Int), row, col)
+	return new(big.Rat).SetFrac(e, den)
+}
+
+func (B *blockTableau) variables() *tableauVariables {
+	return B.vars
+}
+
+func (B *blockTableau) rhsCol() int {
+	return B.vars.n + 1
+}
+
+func (B *blockTableau) sign(row int, col int) int {
+	e, sign := B.rowEntry(B.entries[0], row, col)
+	return e.Sign() * sign
+}
+
+func (B *blockTableau) ratioTest(rowA int, rowB int, colA int, colB int) int {
+	aA, signA := B.rowEntry(B.entries[0], rowA, colA)
+	aB, _ := B.rowEntry(B.entries[1], rowA, colB)
+	bA, signB := B.rowEntry(B.entries[2], rowB, colA)
+	bB, _ := B.rowEntry(B.entries[3], rowB, colB)
+	a := aB.Mul(aB, bA)
+	b := bB.Mul(bB, aA)
+	return a.Cmp(b) * signA * signB
+}
+
+/*
+ * pivot  enter  in for  leave  in the full basis.  Usually both are in
+ * the same block.  If not, z0 is basic in the block of  enter, so
+ * enter  replaces z0 there and z0 replaces  leave  in the other block.
+ */
+func (B *blockTableau) pivot(leave *tableauVariable, enter *tableauVariable) (int, int, error) {
+
+	if !leave.isBasic() || enter.isBasic() {
+		return 0, 0, fmt.Errorf("%w: %v cannot replace %v", ErrBadPivot, enter, leave)
+	}
+
+	if B.sign(leave.row(), enter.col()) == 0 {
+		return 0, 0, fmt.Errorf("%w: %v cannot replace %v on a zero", ErrBadPivot, enter, leave)
+	}
+
+	D, other := B.home(leave)
+	if D.col[enter.idx] >= 0 {
+		D.pivot(leave, enter, B.workers)
+	} else {
+		z0 := B.vars.z(0)
+		other.pivot(z0, enter, B.workers)
+		D.pivot(leave, z0, B.workers)
+	}
+
+	B.detSign = 1
+	row, col := B.vars.swap(enter, leave)
+	return row, col, nil
+}
+
+func (B *blockTableau) result(status Status, pivots int) *Result {
+
+	n := B.vars.n
+	res := &Result{
+		Status: status,
+		Z:      make([]*big.Rat, n),
+		W:      make([]*big.Rat, n),
+		Z0:     B.varValue(B.vars.z(0)),
+		Basis:  make([]Variable, n),
+		Pivots: pivots,
+	}
+
+	for i := 0; i < n; i++ {
+		res.Z[i] = B.varValue(B.vars.z(i + 1))
+		res.W[i] = B.varValue(B.vars.w(i + 1))
+		res.Basis[i] = B.vars.fromRow(i).variable()
+	}
+	for _, D := range B.blocks {
+		res.FillIn += D.store.fillIn()
+	}
+	return res
+}
+
+/*
+ * varValue is the value of any variable, zero if cobasic:
+ * Z(i):  scfa[i] A[row][RHS] / (scfa[RHS] det)
+ * W(i):  A[row][RHS] / (scfa[RHS] det)
+ */
+func (B *blockTableau) varValue(v *tableauVariable) *big.Rat {
+
+	if !v.isBasic() {
+		return new(big.Rat)
+	}
+
+	value := B.fullRatio(v.row(), B.rhsCol())
+	scale := new(big.Rat).SetFrac(big.NewInt(1), B.scaleFactors[B.rhsCol()])
+	if v.isZ() {
+		scale.Mul(scale, new(big.Rat).SetInt(B.scaleFactors[v.idx]))
+	}
+	return value.Mul(value, scale)
+}
+
+// ray is that of the integer tableau divided by its determinant.
+func (B *blockTableau) ray(enter *tableauVariable) []*big.Rat {
+
+	n := B.vars.n
+	dir := make([]*big.Rat, n+1)
+	for i := 0; i <= n; i++ {
+		dir[i] = new(big.Rat)
+	}
+
+	if enter.isZ() {
+		dir[enter.idx].SetInt(B.scaleFactors[enter.idx])
+	}
+
+	col := enter.col()
+	for i := 0; i < n; i++ {
+		basic := B.vars.fromRow(i)
+		if basic.isZ() {
+			value := B.fullRatio(i, col)
+			value.Mul(value, new(big.Rat).SetInt(B.scaleFactors[basic.idx]))
+			dir[basic.idx] = value.Neg(value)
+		}
+	}
+	return dir
+}
+
+// pivotStep has no determinant and no snapshot, there is no full
+// integer tableau to take them from.
+func (B *blockTableau) pivotStep(count int, enter *tableauVariable, leave *tableauVariable, row int, col int, snapshot bool) *PivotStep {
+	return &PivotStep{
+		Count: count,
+		Enter: enter.variable(),
+		Leave: leave.variable(),
+		Row:   row,
+		Col:   col,
+		Z0:    B.varValue(B.vars.z(0)),
+	}
+}
+
+// checkBlock rejects the options the block tableau of Options.BlockSize
+// could not honour.
+func (opts *Options) checkBlock() error {
+	if opts.BlockSize == 0 {
+		return nil
+	}
+	switch {
+	case opts.BlockSize < 0:
+		return fmt.Errorf("%w: block size %d", ErrDimension, opts.BlockSize)
+	case opts.Method != Lemke:
+		return fmt.Errorf("%w: BlockSize only applies to Lemke's method, not %v", ErrUnsupported, opts.Method)
+	case opts.FloatPivoting:
+		return fmt.Errorf("%w: the block tableau does not pivot in floats", ErrUnsupported)
+	case opts.WarmStart != nil:
+		return fmt.Errorf("%w: the block tableau cannot start warm", ErrUnsupported)
+	case opts.Snapshots:
+		return fmt.Errorf("%w: the block tableau has no integer tableau to snapshot", ErrUnsupported)
+	}
+	return nil
+}
+
+// startBlock starts Lemke's algorithm on the block tableau, see
+// Options.BlockSize.
+func startBlock(lcp *LCP, d []*big.Rat, opts *Options) (*Solver, error) {
+
+	if err := checkInputs(lcp.q, d); err != nil {
+		return nil, err
+	}
+
+	B, err := newBlockTableau(lcp, d, opts.BlockSize, opts.Storage)
+	if err != nil {
+		return nil, err
+	}
+	B.workers = opts.Workers
+
+	// z0 enters the basis to obtain lex-feasible solution
+	enter := B.vars.z(0)
+	rule, _ := opts.pivotRule(lcp.n)
+	leave, z0leave, ties, err := minratio(B, enter, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	// now give the entering q-col its correct sign
+	for _, D := range B.blocks {
+		D.store.negateCol(D.ncols - 1)
+	}
+
+	return newSolver(B, opts, enter, leave, z0leave, ties), nil
+}
+
+// snapshot is nil, there is no integer tableau to copy.
+func (B *blockTableau) snapshot() *Snapshot {
+	return nil
+}
@@ -0,0 +1,160 @@
+package lemke
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// LCP of a random m x m game with payoffs in -values..-1 as in package
+// nash: x, u, y, v with  -A y + u 1 >= 0, 1'x = 1  and alike for B.
+// Few payoff values make it degenerate.
+func bimatrixTestLCP(t *testing.T, m int, values int, seed int64) (*LCP, []*big.Rat) {
+
+	r := rand.New(rand.NewSource(seed))
+	n := 2*m + 2
+	M := make([]*big.Rat, n*n)
+	for i := range M {
+		M[i] = new(big.Rat)
+	}
+	q := make([]*big.Rat, n)
+	for i := range q {
+		q[i] = new(big.Rat)
+	}
+
+	u, v := m, 2*m+1
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			M[i*n+m+1+j].SetInt64(int64(r.Intn(values) + 1))
+			M[(m+1+j)*n+i].SetInt64(int64(r.Intn(values) + 1))
+		}
+		M[i*n+v].SetInt64(-1)
+		M[v*n+i].SetInt64(1)
+		M[(m+1+i)*n+u].SetInt64(-1)
+		M[u*n+m+1+i].SetInt64(1)
+	}
+	q[u].SetInt64(-1)
+	q[v].SetInt64(-1)
+
+	d := make([]*big.Rat, n)
+	for i := range d {
+		d[i] = big.NewRat(1, 1)
+	}
+	return newTestLCP(t, M, q), d
+}
+
+func assertSameResult(t *testing.T, expected *Result, actual *Result) {
+	assert.Equal(t, expected.Status, actual.Status)
+	assert.Equal(t, solutionKey(expected.Z), solutionKey(actual.Z))
+	assert.Equal(t, solutionKey(expected.W), solutionKey(actual.W))
+	assert.Equal(t, expected.Z0.RatString(), actual.Z0.RatString())
+	assert.Equal(t, expected.Basis, actual.Basis)
+	assert.Equal(t, expected.Pivots, actual.Pivots)
+	assert.Equal(t, expected.Ties, actual.Ties)
+}
+
+func TestBlockMatchesFullTableau(t *testing.T) {
+
+	for seed := int64(1); seed <= 20; seed++ {
+		// the largest payoffs overflow machine words on the way
+		for _, values := range []int{2, 100, 1 << 40} {
+			lcp, d := bimatrixTestLCP(t, 4, values, seed)
+
+			full, err := SolveResult(lcp, d, nil)
+			assert.Nil(t, err)
+
+			for _, kind := range []StorageKind{AutoStorage, DenseStorage, SparseStorage} {
+				block, err := SolveResult(lcp, d, &Options{BlockSize: 5, Storage: kind})
+				assert.Nil(t, err)
+				assertSameResult(t, full, block)
+			}
+		}
+	}
+}
+
+func TestBlockPivotRules(t *testing.T) {
+
+	lcp, d := bimatrixTestLCP(t, 3, 2, 7)
+	for _, rule := range []PivotRule{LeastIndex, Perturbation{7, 6, 5, 4, 3, 2, 1, 8}} {
+		full, err := SolveResult(lcp, d, &Options{PivotRule: rule})
+		assert.Nil(t, err)
+
+		block, err := SolveResult(lcp, d, &Options{PivotRule: rule, BlockSize: 4})
+		assert.Nil(t, err)
+		assertSameResult(t, full, block)
+	}
+}
+
+func TestBlockRayTermination(t *testing.T) {
+
+	// w1 = -1 - z2 + z0, w2 = -1 - z1 + z0
+	M := ints2rats([]int{0, 1, 1, 0})
+	M[1].SetInt64(-1)
+	M[2].SetInt64(-1)
+	lcp := newTestLCP(t, M, ints2rats([]int{-1, -1}))
+	d := ints2rats([]int{1, 1})
+
+	full, err := SolveResult(lcp, d, nil)
+	assert.True(t, errors.Is(err, ErrRayTermination))
+
+	block, err := SolveResult(lcp, d, &Options{BlockSize: 1})
+	assert.True(t, errors.Is(err, ErrRayTermination))
+	assertSameResult(t, full, block)
+
+	// same direction, up to a positive scale
+	for i := range full.Ray {
+		assert.Equal(t, full.Ray[i].Sign(), block.Ray[i].Sign())
+	}
+}
+
+func TestBlockNeedsZeroDiagonalBlocks(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{1, 1, 1, 0}), ints2rats([]int{-1, -1}))
+	d := ints2rats([]int{1, 1})
+
+	_, err := SolveResult(lcp, d, &Options{BlockSize: 1})
+	assert.True(t, errors.Is(err, ErrMatrixClass))
+
+	_, err = SolveResult(lcp, d, &Options{BlockSize: 2})
+	assert.True(t, errors.Is(err, ErrDimension))
+}
+
+func TestBlockRejectsIgnoredOptions(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{0, 1, 1, 0}), ints2rats([]int{-1, -1}))
+	d := ints2rats([]int{1, 1})
+
+	for _, opts := range []*Options{
+		{BlockSize: 1, FloatPivoting: true},
+		{BlockSize: 1, WarmStart: []Variable{W(1), W(2)}},
+		{BlockSize: 1, Snapshots: true},
+		{BlockSize: 1, Method: PrincipalPivoting},
+	} {
+		_, err := SolveResult(lcp, d, opts)
+		assert.True(t, errors.Is(err, ErrUnsupported), "%+v", opts)
+	}
+
+	_, err := NewSolver(lcp, d, &Options{BlockSize: 1, Snapshots: true})
+	assert.True(t, errors.Is(err, ErrUnsupported))
+
+	_, err = SolveResult(lcp, d, &Options{BlockSize: -1})
+	assert.True(t, errors.Is(err, ErrDimension))
+}
+
+// BenchmarkBlock compares the block tableau with the full one on a
+// 40 x 40 game.
+func BenchmarkBlock(b *testing.B) {
+
+	lcp, d := bimatrixTestLCP(&testing.T{}, 40, 20, 1)
+	for _, blockSize := range []int{0, 41} {
+		b.Run(fmt.Sprintf("blocksize=%d", blockSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				SolveResult(lcp, d, &Options{BlockSize: blockSize})
+			}
+		})
+	}
+}
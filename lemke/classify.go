@@ -0,0 +1,499 @@
+package lemke
+
+import (
+	"math/big"
+	"math/rand"
+)
+
+// ExactClassLimit is the largest n for which the exponential exact tests
+// of IsPMatrix, IsCopositive, IsCopositivePlus and IsStrictlySemimonotone
+// run.  Above it they look for witnesses among HeuristicSamples random
+// principal submatrices and all of order at most 2.
+var ExactClassLimit = 10
+
+// HeuristicSamples is the number of random principal submatrices tried
+// by the heuristic tests.
+var HeuristicSamples = 200
+
+// Property is a matrix class that M may belong to.
+type Property int
+
+const (
+	// PMatrix: every principal minor of M is positive.  LCP(q, M) then has
+	// a unique solution for every q.
+	PMatrix Property = iota
+	// PositiveSemidefinite: x^T M x >= 0 for every x.
+	PositiveSemidefinite
+	// Copositive: x^T M x >= 0 for every x >= 0.
+	Copositive
+	// CopositivePlus: copositive, and x >= 0 with x^T M x = 0 implies
+	// (M + M^T) x = 0.  Lemke then processes every feasible LCP.
+	CopositivePlus
+	// ZMatrix: every off-diagonal entry of M is nonpositive.
+	ZMatrix
+	// StrictlySemimonotone: for every x >= 0, x != 0, there is some i with
+	// x(i) > 0 and (Mx)(i) > 0.
+	StrictlySemimonotone
+)
+
+func (p Property) String() string {
+	switch p {
+	case PMatrix:
+		return "P-matrix"
+	case PositiveSemidefinite:
+		return "positive semidefinite"
+	case Copositive:
+		return "copositive"
+	case CopositivePlus:
+		return "copositive-plus"
+	case ZMatrix:
+		return "Z-matrix"
+	case StrictlySemimonotone:
+		return "strictly semimonotone"
+	}
+	return "unknown"
+}
+
+// ClassReport tells whether M has a Property.
+type ClassReport struct {
+	Property Property
+	Holds    bool
+
+	// Exact is false if Holds only means that a heuristic search found no
+	// witness.  A failure always comes with a Witness and is exact.
+	Exact bool
+
+	// Witness shows why the property fails, nil if it holds.
+	Witness *Witness
+}
+
+// Witness of a failed property, which fields are set depends on it:
+//   - PMatrix: Indices of a principal submatrix with determinant Minor <= 0.
+//   - ZMatrix: Indices i, j of a positive entry M(i,j).
+//   - PositiveSemidefinite: Vector x with x^T M x < 0.
+//   - Copositive: Vector x >= 0 with x^T M x < 0.
+//   - CopositivePlus: as for Copositive, or x >= 0 with x^T M x = 0 but
+//     (M + M^T) x != 0.
+//   - StrictlySemimonotone: Vector x >= 0, x != 0, with (Mx)(i) <= 0
+//     wherever x(i) > 0.
+//
+// Indices are 0-based.
+type Witness struct {
+	Indices []int
+	Minor   *big.Rat
+	Vector  []*big.Rat
+}
+
+// Classify reports on every Property, in the order they are declared.
+func (lcp *LCP) Classify() []*ClassReport {
+	return []*ClassReport{
+		lcp.IsPMatrix(),
+		lcp.IsPositiveSemidefinite(),
+		lcp.IsCopositive(),
+		lcp.IsCopositivePlus(),
+		lcp.IsZMatrix(),
+		lcp.IsStrictlySemimonotone(),
+	}
+}
+
+// IsZMatrix checks the signs of the off-diagonal entries of M.
+func (lcp *LCP) IsZMatrix() *ClassReport {
+	for i := 0; i < lcp.n; i++ {
+		for j := 0; j < lcp.n; j++ {
+			if i != j && lcp.M(i, j).Sign() > 0 {
+				return failed(ZMatrix, &Witness{Indices: []int{i, j}})
+			}
+		}
+	}
+	return holds(ZMatrix, true)
+}
+
+// IsPositiveSemidefinite is always exact, it factorizes the symmetric
+// part of M.
+func (lcp *LCP) IsPositiveSemidefinite() *ClassReport {
+	if x, _ := lcp.matrix().symmetricPart().semidefinite(); x != nil {
+		return failed(PositiveSemidefinite, &Witness{Vector: x})
+	}
+	return holds(PositiveSemidefinite, true)
+}
+
+// IsPMatrix computes principal minors.  A positive definite symmetric
+// part settles it without them, whatever n.
+func (lcp *LCP) IsPMatrix() *ClassReport {
+
+	A := lcp.matrix()
+	if _, definite := A.symmetricPart().semidefinite(); definite {
+		return holds(PMatrix, true)
+	}
+
+	var witness *Witness
+	exact := lcp.forPrincipalSubsets(func(idx []int) bool {
+		if minor := A.sub(idx, idx).det(); minor.Sign() <= 0 {
+			witness = &Witness{Indices: idx, Minor: minor}
+			return false
+		}
+		return true
+	})
+
+	if witness != nil {
+		return failed(PMatrix, witness)
+	}
+	return holds(PMatrix, exact)
+}
+
+/*
+ * IsCopositive
+ * ================================================================
+ * by the criterion of Cottle, Habetler and Lemke: if all principal
+ * submatrices of order k-1 of a symmetric  S_J  of order k are
+ * copositive, then  S_J  is not copositive iff it has an inverse
+ * with no positive entry, and then  x = -S_J^-1 1  is a witness.
+ * Going through the submatrices by increasing order the first one
+ * that fails is therefore found.
+ */
+
+// IsCopositive tests the principal submatrices of the symmetric part of
+// M by increasing order.  Nonnegative and positive semidefinite M are
+// recognised whatever n.
+func (lcp *LCP) IsCopositive() *ClassReport {
+
+	S := lcp.matrix().symmetricPart()
+	if S.nonnegative() {
+		return holds(Copositive, true)
+	}
+	if x, _ := S.semidefinite(); x == nil {
+		return holds(Copositive, true)
+	}
+
+	var witness *Witness
+	exact := lcp.forPrincipalSubsets(func(idx []int) bool {
+		inv, ok := S.sub(idx, idx).inverse()
+		if !ok || !inv.nonpositive() {
+			return true
+		}
+		x := make([]*big.Rat, lcp.n)
+		for i := range x {
+			x[i] = new(big.Rat)
+		}
+		for i, row := range inv {
+			for _, entry := range row {
+				x[idx[i]].Sub(x[idx[i]], entry)
+			}
+		}
+		witness = &Witness{Vector: x}
+		return false
+	})
+
+	if witness != nil {
+		return failed(Copositive, witness)
+	}
+	return holds(Copositive, exact)
+}
+
+/*
+ * IsCopositivePlus
+ * ================================================================
+ * for copositive  S  every  x >= 0  with  x^T S x = 0  minimizes the
+ * form on the orthant, so  Sx >= 0.  If some such x has  Sx != 0  then
+ * so has one of least support L, and there  S_LL  has a one
+ * dimensional null space spanned by  x_L > 0, else  x  would be a
+ * convex combination of two such vectors of smaller support.
+ */
+
+// IsCopositivePlus first checks copositivity, then the null spaces of
+// the principal submatrices of the symmetric part of M.
+func (lcp *LCP) IsCopositivePlus() *ClassReport {
+
+	report := lcp.IsCopositive()
+	if !report.Holds {
+		report.Property = CopositivePlus
+		return report
+	}
+
+	S := lcp.matrix().symmetricPart()
+	if x, _ := S.semidefinite(); x == nil {
+		return holds(CopositivePlus, report.Exact)
+	}
+
+	var witness *Witness
+	exact := lcp.forPrincipalSubsets(func(idx []int) bool {
+		null := S.sub(idx, idx).nullSpace()
+		if len(null) != 1 || !sameSign(null[0]) {
+			return true
+		}
+		x := embed(lcp.n, idx, null[0])
+		if isZero(S.mulVec(x)) {
+			return true
+		}
+		witness = &Witness{Vector: x}
+		return false
+	})
+
+	if witness != nil {
+		return failed(CopositivePlus, witness)
+	}
+	return holds(CopositivePlus, exact && report.Exact)
+}
+
+/*
+ * IsStrictlySemimonotone
+ * ================================================================
+ * let  x  be a violating vector of least support L, scaled to sum 1.
+ * The polytope  { y >= 0, sum y = 1, M_LL y <= 0 }  contains it, and
+ * all of its vertices have support L, so one vertex has  |L| - 1
+ * independent tight rows of  M_LL.  Solving for every choice of the
+ * row left out finds it.
+ */
+
+// IsStrictlySemimonotone looks for a violating vertex on every principal
+// submatrix.  M with a positive definite symmetric part is recognised
+// whatever n.
+func (lcp *LCP) IsStrictlySemimonotone() *ClassReport {
+
+	A := lcp.matrix()
+	if _, definite := A.symmetricPart().semidefinite(); definite {
+		return holds(StrictlySemimonotone, true)
+	}
+
+	var witness *Witness
+	exact := lcp.forPrincipalSubsets(func(idx []int) bool {
+		k := len(idx)
+		B := A.sub(idx, idx)
+		for drop := 0; drop < k; drop++ {
+
+			system := newRatMatrix(k, k)
+			rhs := make([]*big.Rat, k)
+			for i := 0; i < k-1; i++ {
+				row := i
+				if i >= drop {
+					row++
+				}
+				for j := 0; j < k; j++ {
+					system[i][j].Set(B[row][j])
+				}
+				rhs[i] = new(big.Rat)
+			}
+			for j := 0; j < k; j++ {
+				system[k-1][j].SetInt64(1)
+			}
+			rhs[k-1] = big.NewRat(1, 1)
+
+			y, ok := system.solve(rhs)
+			if !ok || !positive(y) || !nonpositive(B.mulVec(y)) {
+				continue
+			}
+			witness = &Witness{Vector: embed(lcp.n, idx, y)}
+			return false
+		}
+		return true
+	})
+
+	if witness != nil {
+		return failed(StrictlySemimonotone, witness)
+	}
+	return holds(StrictlySemimonotone, exact)
+}
+
+func holds(p Property, exact bool) *ClassReport {
+	return &ClassReport{Property: p, Holds: true, Exact: exact}
+}
+
+func failed(p Property, witness *Witness) *ClassReport {
+	return &ClassReport{Property: p, Holds: false, Exact: true, Witness: witness}
+}
+
+/*
+ * forPrincipalSubsets
+ * ================================================================
+ * call  visit  with the index set of every principal submatrix, by
+ * increasing order, until it returns false.  Above ExactClassLimit
+ * only those of order at most 2 and HeuristicSamples random ones.
+ * @return false if only a sample was visited
+ */
+func (lcp *LCP) forPrincipalSubsets(visit func(idx []int) bool) bool {
+
+	n := lcp.n
+	maxSize := n
+	if n > ExactClassLimit {
+		maxSize = 2
+	}
+
+	for k := 1; k <= maxSize; k++ {
+		idx := make([]int, k)
+		for i := range idx {
+			idx[i] = i
+		}
+		for {
+			if !visit(append([]int(nil), idx...)) {
+				return maxSize == n
+			}
+
+			// next combination of k out of n
+			i := k - 1
+			for i >= 0 && idx[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				break
+			}
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[j-1] + 1
+			}
+		}
+	}
+
+	if maxSize == n {
+		return true
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for s := 0; s < HeuristicSamples; s++ {
+		var idx []int
+		for i := 0; i < n; i++ {
+			if r.Intn(2) == 0 {
+				idx = append(idx, i)
+			}
+		}
+		if len(idx) > 0 && !visit(idx) {
+			break
+		}
+	}
+	return false
+}
+
+/*
+ * semidefinite
+ * ================================================================
+ * symmetric Gaussian elimination  X^T S X  of a symmetric matrix,
+ * keeping track of the columns of  X.  A negative pivot  k  means
+ * X_k  has a negative form, a zero pivot with nonzero entry  S_kj
+ * means some  a X_k + X_j  has.
+ * @return such a vector, nil if S is positive semidefinite,
+ * and whether S is positive definite
+ */
+func (S ratMatrix) semidefinite() ([]*big.Rat, bool) {
+
+	n := len(S)
+	S = S.clone()
+	X := newRatMatrix(n, n) // X[k] is the k-th column of X
+	for k := 0; k < n; k++ {
+		X[k][k].SetInt64(1)
+	}
+
+	definite := true
+	tmp := new(big.Rat)
+	for k := 0; k < n; k++ {
+
+		if S[k][k].Sign() < 0 {
+			return X[k], false
+		}
+
+		if S[k][k].Sign() == 0 {
+			definite = false
+			for j := k + 1; j < n; j++ {
+				if S[k][j].Sign() == 0 {
+					continue
+				}
+				// form of  a X_k + X_j  is  2a S_kj + S_jj = -1
+				a := new(big.Rat).Add(S[j][j], big.NewRat(1, 1))
+				a.Quo(a, new(big.Rat).Mul(big.NewRat(-2, 1), S[k][j]))
+				x := make([]*big.Rat, n)
+				for i := range x {
+					x[i] = new(big.Rat).Mul(a, X[k][i])
+					x[i].Add(x[i], X[j][i])
+				}
+				return x, false
+			}
+			continue
+		}
+
+		for j := k + 1; j < n; j++ {
+			if S[k][j].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Quo(S[k][j], S[k][k])
+			for i := 0; i < n; i++ {
+				X[j][i].Sub(X[j][i], tmp.Mul(factor, X[k][i]))
+			}
+			for i := k + 1; i < n; i++ {
+				S[i][j].Sub(S[i][j], tmp.Mul(factor, S[i][k]))
+			}
+		}
+		for j := k + 1; j < n; j++ {
+			S[k][j].SetInt64(0)
+			S[j][k].SetInt64(0)
+		}
+	}
+	return nil, definite
+}
+
+func (A ratMatrix) nonnegative() bool {
+	for _, row := range A {
+		for _, entry := range row {
+			if entry.Sign() < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (A ratMatrix) nonpositive() bool {
+	for _, row := range A {
+		if !nonpositive(row) {
+			return false
+		}
+	}
+	return true
+}
+
+func nonpositive(x []*big.Rat) bool {
+	for _, value := range x {
+		if value.Sign() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func positive(x []*big.Rat) bool {
+	for _, value := range x {
+		if value.Sign() <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isZero(x []*big.Rat) bool {
+	for _, value := range x {
+		if value.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sameSign reports whether all entries of x are nonzero and of the same
+// sign, which is then made positive.
+func sameSign(x []*big.Rat) bool {
+	if !positive(x) {
+		for _, value := range x {
+			value.Neg(value)
+		}
+	}
+	return positive(x)
+}
+
+// embed the entries of x at the positions idx of an n vector of zeros
+func embed(n int, idx []int, x []*big.Rat) []*big.Rat {
+	y := make([]*big.Rat, n)
+	for i := range y {
+		y[i] = new(big.Rat)
+	}
+	for i, pos := range idx {
+		y[pos].Set(x[i])
+	}
+	return y
+}
@@ -0,0 +1,164 @@
+package lemke
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func squareTestLCP(t *testing.T, M []int) *LCP {
+	n := 1
+	for n*n < len(M) {
+		n++
+	}
+	q := make([]int, n)
+	for i := range q {
+		q[i] = -1
+	}
+	return newTestLCP(t, ints2rats(M), ints2rats(q))
+}
+
+var horn = []int{
+	1, -1, 1, 1, -1,
+	-1, 1, -1, 1, 1,
+	1, -1, 1, -1, 1,
+	1, 1, -1, 1, -1,
+	-1, 1, 1, -1, 1,
+}
+
+func TestZMatrix(t *testing.T) {
+
+	report := squareTestLCP(t, []int{2, -1, -1, 2}).IsZMatrix()
+	assert.True(t, report.Holds)
+	assert.True(t, report.Exact)
+
+	report = squareTestLCP(t, []int{1, 2, 0, 1}).IsZMatrix()
+	assert.False(t, report.Holds)
+	assert.Equal(t, []int{0, 1}, report.Witness.Indices)
+}
+
+func TestPositiveSemidefinite(t *testing.T) {
+
+	assert.True(t, squareTestLCP(t, []int{1, -1, -1, 1}).IsPositiveSemidefinite().Holds)
+	assert.True(t, squareTestLCP(t, []int{0, 1, -1, 0}).IsPositiveSemidefinite().Holds)
+
+	for _, M := range [][]int{{1, 2, 2, 1}, {0, 1, 1, 0}, horn} {
+		lcp := squareTestLCP(t, M)
+		report := lcp.IsPositiveSemidefinite()
+		assert.False(t, report.Holds)
+		assert.Equal(t, -1, lcp.matrix().quadForm(report.Witness.Vector).Sign())
+	}
+}
+
+func TestPMatrix(t *testing.T) {
+
+	report := squareTestLCP(t, []int{2, 1, 1, 3}).IsPMatrix()
+	assert.True(t, report.Holds)
+	assert.True(t, report.Exact)
+
+	// not positive definite but all minors are 1
+	report = squareTestLCP(t, []int{1, -3, 0, 1}).IsPMatrix()
+	assert.True(t, report.Holds)
+	assert.True(t, report.Exact)
+
+	report = squareTestLCP(t, []int{1, 2, 2, 1}).IsPMatrix()
+	assert.False(t, report.Holds)
+	assert.Equal(t, []int{0, 1}, report.Witness.Indices)
+	assert.Equal(t, "-3", report.Witness.Minor.RatString())
+}
+
+func TestCopositive(t *testing.T) {
+
+	assert.True(t, squareTestLCP(t, []int{0, 1, 1, 0}).IsCopositive().Holds)
+
+	report := squareTestLCP(t, horn).IsCopositive()
+	assert.True(t, report.Holds)
+	assert.True(t, report.Exact)
+
+	lcp := squareTestLCP(t, []int{1, -2, 0, 0, -2, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1})
+	report = lcp.IsCopositive()
+	assert.False(t, report.Holds)
+	x := report.Witness.Vector
+	assert.Equal(t, "1 1 0 0 ", solutionKey(x))
+	assert.Equal(t, -1, lcp.matrix().quadForm(x).Sign())
+}
+
+func TestCopositivePlus(t *testing.T) {
+
+	assert.True(t, squareTestLCP(t, []int{0, 1, -1, 0}).IsCopositivePlus().Holds)
+	assert.True(t, squareTestLCP(t, []int{1, 1, 1, 1}).IsCopositivePlus().Holds)
+
+	lcp := squareTestLCP(t, []int{0, 1, 1, 0})
+	report := lcp.IsCopositivePlus()
+	assert.False(t, report.Holds)
+	x := report.Witness.Vector
+	assert.Equal(t, 0, lcp.matrix().quadForm(x).Sign())
+	assert.False(t, isZero(lcp.matrix().symmetricPart().mulVec(x)))
+
+	report = squareTestLCP(t, []int{1, -2, -2, 1}).IsCopositivePlus()
+	assert.False(t, report.Holds)
+	assert.Equal(t, CopositivePlus, report.Property)
+}
+
+func TestStrictlySemimonotone(t *testing.T) {
+
+	report := squareTestLCP(t, []int{1, 2, 2, 1}).IsStrictlySemimonotone()
+	assert.True(t, report.Holds)
+	assert.True(t, report.Exact)
+
+	for _, M := range [][]int{{0, 1, 1, 0}, {1, -1, -1, 1}, {1, 2, -1, -1}} {
+		lcp := squareTestLCP(t, M)
+		report = lcp.IsStrictlySemimonotone()
+		assert.False(t, report.Holds)
+
+		x := report.Witness.Vector
+		Mx := lcp.matrix().mulVec(x)
+		assert.False(t, isZero(x))
+		for i := range x {
+			assert.True(t, x[i].Sign() >= 0)
+			assert.True(t, x[i].Sign() == 0 || Mx[i].Sign() <= 0)
+		}
+	}
+}
+
+func TestClassifyHeuristic(t *testing.T) {
+
+	defer func(limit int) { ExactClassLimit = limit }(ExactClassLimit)
+	ExactClassLimit = 3
+
+	report := squareTestLCP(t, horn).IsCopositive()
+	assert.True(t, report.Holds)
+	assert.False(t, report.Exact)
+
+	report = squareTestLCP(t, horn).IsPMatrix()
+	assert.False(t, report.Holds)
+	assert.True(t, report.Exact)
+}
+
+func TestClassify(t *testing.T) {
+
+	reports := squareTestLCP(t, []int{2, -1, -1, 2}).Classify()
+	assert.Equal(t, 6, len(reports))
+	for i, report := range reports {
+		assert.Equal(t, Property(i), report.Property)
+		assert.True(t, report.Holds, report.Property.String())
+	}
+}
+
+func TestRatMatrixInverse(t *testing.T) {
+
+	A := ratMatrix{
+		{big.NewRat(2, 1), big.NewRat(1, 1)},
+		{big.NewRat(1, 1), big.NewRat(1, 1)},
+	}
+	inv, ok := A.inverse()
+	assert.True(t, ok)
+	assert.Equal(t, "1", inv[0][0].RatString())
+	assert.Equal(t, "-1", inv[0][1].RatString())
+	assert.Equal(t, "2", inv[1][1].RatString())
+	assert.Equal(t, "1", A.det().RatString())
+
+	_, ok = ratMatrix{{big.NewRat(1, 1), big.NewRat(2, 1)}, {big.NewRat(2, 1), big.NewRat(4, 1)}}.inverse()
+	assert.False(t, ok)
+}
@@ -0,0 +1,52 @@
+package lemke
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveContextAlreadyCanceled(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{-1, -1}))
+	d := ints2rats([]int{2, 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	z, err := SolveContext(ctx, lcp, d)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 2, len(z))
+
+	var canceled *CanceledError
+	assert.True(t, errors.As(err, &canceled))
+	assert.Equal(t, Canceled, canceled.Result.Status)
+	assert.Equal(t, 0, canceled.Result.Pivots)
+	assert.Equal(t, []Variable{W(1), W(2)}, canceled.Result.Basis)
+	assert.Equal(t, "-1", canceled.Result.W[0].RatString())
+}
+
+func TestSolveContextCanceledMidway(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{-1, -1}))
+	d := ints2rats([]int{2, 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := &Options{
+		Observer: ObserverFunc(func(step *PivotStep) {
+			if step.Count == 1 {
+				cancel()
+			}
+		}),
+	}
+
+	res, err := SolveResultContext(ctx, lcp, d, opts)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, Canceled, res.Status)
+	assert.Equal(t, 1, res.Pivots)
+	assert.Contains(t, res.Basis, Z(0))
+}
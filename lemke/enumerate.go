@@ -0,0 +1,99 @@
+package lemke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// MaxEnumerate is the largest LCP size Enumerate accepts.  The work
+// doubles with every extra row.
+var MaxEnumerate = 20
+
+// Enumerate returns every complementary basic solution of the LCP, one
+// Result per distinct z, in the order they are found.  If M is
+// nondegenerate these are all solutions.  Otherwise a solution set may be
+// a whole polyhedron of which only the vertices are returned.
+func Enumerate(lcp *LCP) ([]*Result, error) {
+	return EnumerateContext(context.Background(), lcp)
+}
+
+// EnumerateContext is Enumerate but gives up as soon as ctx is done,
+// returning the solutions found so far along with ctx.Err().
+func EnumerateContext(ctx context.Context, lcp *LCP) ([]*Result, error) {
+
+	if lcp.n > MaxEnumerate {
+		return nil, fmt.Errorf("%w: %d rows are too many to enumerate, at most %d", ErrDimension, lcp.n, MaxEnumerate)
+	}
+
+	d := make([]*big.Rat, lcp.n)
+	for i := range d {
+		d[i] = new(big.Rat) // z0 stays cobasic, its column does not matter
+	}
+
+	tableau, scaleFactors := fillTableau(lcp, d, AutoStorage)
+	tableau.negateCol(tableau.rhsCol())
+
+	seen := make(map[string]bool)
+	var solutions []*Result
+
+	basis := make([]Variable, lcp.n)
+	for i := range basis {
+		basis[i] = W(i + 1)
+	}
+
+	/*
+	 * visit the complementary bases in Gray code order, so each differs
+	 * from the one before in a single pair  z(i), w(i)  and usually
+	 * costs one pivot.  Singular ones are skipped, but factorize may have
+	 * pivoted partway towards them first, so the tableau is left at some
+	 * other basis and the next one may need several pivots.
+	 */
+	count := uint64(1) << uint(lcp.n)
+	for k := uint64(0); k < count; k++ {
+
+		if ctx.Err() != nil {
+			return solutions, ctx.Err()
+		}
+
+		if k > 0 {
+			i := bits.TrailingZeros64(k)
+			if basis[i].IsZ() {
+				basis[i] = W(i + 1)
+			} else {
+				basis[i] = Z(i + 1)
+			}
+
+			if _, err := tableau.factorize(basis, nil); errors.Is(err, ErrBadBasis) {
+				continue
+			} else if err != nil {
+				return solutions, err
+			}
+		}
+
+		if !tableau.feasible() {
+			continue
+		}
+
+		res := newResult(tableau, scaleFactors, Solved, 0)
+		key := solutionKey(res.Z)
+		if !seen[key] {
+			seen[key] = true
+			solutions = append(solutions, res)
+		}
+	}
+
+	return solutions, nil
+}
+
+func solutionKey(z []*big.Rat) string {
+	var buf strings.Builder
+	for _, value := range z {
+		buf.WriteString(value.RatString())
+		buf.WriteByte(' ')
+	}
+	return buf.String()
+}
@@ -0,0 +1,74 @@
+package lemke
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func solutionStrings(results []*Result) []string {
+	keys := make([]string, len(results))
+	for i, res := range results {
+		keys[i] = solutionKey(res.Z)
+	}
+	return keys
+}
+
+func TestEnumerateThreeSolutions(t *testing.T) {
+
+	M := ints2rats([]int{1, 2, 2, 1})
+	q := ints2rats([]int{-1, -1})
+	lcp := newTestLCP(t, M, q)
+
+	solutions, err := Enumerate(lcp)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"1 0 ", "0 1 ", "1/3 1/3 "}, solutionStrings(solutions))
+
+	for _, res := range solutions {
+		assert.Equal(t, Solved, res.Status)
+		assert.Equal(t, 0, res.Z0.Sign())
+		for i := 0; i < 2; i++ {
+			assert.True(t, res.Z[i].Sign() == 0 || res.W[i].Sign() == 0)
+		}
+	}
+
+	z, err := Solve(lcp, ints2rats([]int{1, 1}))
+	assert.Nil(t, err)
+	assert.Contains(t, solutionStrings(solutions), solutionKey(z))
+}
+
+func TestEnumerateTrivial(t *testing.T) {
+
+	M := ints2rats([]int{1, 0, 0, 1})
+	q := ints2rats([]int{1, 1})
+
+	solutions, err := Enumerate(newTestLCP(t, M, q))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0 0 "}, solutionStrings(solutions))
+}
+
+// {z1, z2} is singular, the segment z1 + z2 = 1 is only seen at its ends
+func TestEnumerateSkipsSingularBasis(t *testing.T) {
+
+	M := ints2rats([]int{1, 1, 1, 1})
+	q := ints2rats([]int{-1, -1})
+
+	solutions, err := Enumerate(newTestLCP(t, M, q))
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"1 0 ", "0 1 "}, solutionStrings(solutions))
+}
+
+func TestEnumerateTooLarge(t *testing.T) {
+
+	n := MaxEnumerate + 1
+	M := make([]int, n*n)
+	q := make([]int, n)
+	for i := 0; i < n; i++ {
+		M[i*n+i] = 1
+		q[i] = -1
+	}
+
+	_, err := Enumerate(newTestLCP(t, ints2rats(M), ints2rats(q)))
+	assert.True(t, errors.Is(err, ErrDimension))
+}
@@ -0,0 +1,71 @@
+package lemke
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by this package.  They are usually wrapped with more
+// detail so test for them with errors.Is.
+var (
+	// ErrDimension means M, q or d do not fit together.
+	ErrDimension = errors.New("lemke: dimension mismatch")
+
+	// ErrRayTermination means no variable could leave the basis, the
+	// algorithm ran off along an unbounded ray.
+	ErrRayTermination = errors.New("lemke: ray termination")
+
+	// ErrTrivialSolution means q >= 0 so z = 0 already solves the LCP
+	// and there is nothing to start Lemke on.
+	ErrTrivialSolution = errors.New("lemke: trivial solution z = 0 since q >= 0")
+
+	// ErrBadCoveringVector means d is negative somewhere or zero where q
+	// is negative.
+	ErrBadCoveringVector = errors.New("lemke: bad covering vector")
+
+	// ErrBadPivot means a pivot was requested that the tableau cannot
+	// perform, e.g. on a zero element or with a variable on the wrong side
+	// of the basis.
+	ErrBadPivot = errors.New("lemke: bad pivot")
+
+	// ErrBadBasis means a set of variables given as a basis is not one,
+	// e.g. it has the wrong size or its columns are dependent.
+	ErrBadBasis = errors.New("lemke: bad basis")
+
+	// ErrMatrixClass means M lacks a property the chosen method relies
+	// on, e.g. it is neither a P-matrix nor positive semidefinite.
+	ErrMatrixClass = errors.New("lemke: matrix of the wrong class")
+
+	// ErrFormat means an LCP file could not be parsed.
+	ErrFormat = errors.New("lemke: bad LCP file")
+
+	// ErrUnsupported means the options ask for something the function
+	// called cannot do, e.g. a Solver for principal pivoting.
+	ErrUnsupported = errors.New("lemke: unsupported options")
+
+	// ErrField means a Field cannot represent a value, e.g. a modulus that
+	// divides a denominator, or is no field at all.
+	ErrField = errors.New("lemke: not representable in the field")
+
+	// ErrInfeasible means the LCP provably has no solution, e.g. a row
+	// with  w_i < 0  for every  z >= 0.
+	ErrInfeasible = errors.New("lemke: LCP has no solution")
+)
+
+// CanceledError is returned when the context of a solve is done before
+// Lemke's algorithm finishes.  Result holds the basis reached so far and
+// Err is the context's error, so errors.Is(err, context.Canceled) and
+// errors.Is(err, context.DeadlineExceeded) work as expected.
+type CanceledError struct {
+	Result *Result
+	Err    error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("lemke: stopped after %d pivots: %v", e.Result.Pivots, e.Err)
+}
+
+// Unwrap returns the context's error.
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
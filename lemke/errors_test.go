@@ -0,0 +1,53 @@
+package lemke
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLCPDimensionErrors(t *testing.T) {
+
+	_, err := NewLCP(ints2rats([]int{1, 2, 3}), ints2rats([]int{-1, -1}))
+	assert.True(t, errors.Is(err, ErrDimension))
+
+	_, err = NewLCP(ints2rats([]int{1, 2, 3, 4}), ints2rats([]int{-1}))
+	assert.True(t, errors.Is(err, ErrDimension))
+
+	_, err = NewLCP(nil, nil)
+	assert.True(t, errors.Is(err, ErrDimension))
+}
+
+func TestCheckInputErrors(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{-1, -1}))
+
+	_, err := Solve(lcp, ints2rats([]int{1}))
+	assert.True(t, errors.Is(err, ErrDimension))
+
+	_, err = Solve(lcp, ints2rats([]int{-1, 1}))
+	assert.True(t, errors.Is(err, ErrBadCoveringVector))
+
+	_, err = Solve(lcp, ints2rats([]int{0, 1}))
+	assert.True(t, errors.Is(err, ErrBadCoveringVector))
+
+	trivial := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{0, 1}))
+	_, err = Solve(trivial, ints2rats([]int{1, 1}))
+	assert.Equal(t, ErrTrivialSolution, err)
+}
+
+func TestPivotOnZeroIsAnError(t *testing.T) {
+
+	A := newTableau(2)
+	for i := 0; i < A.nrows; i++ {
+		for j := 0; j < A.ncols; j++ {
+			A.set(i, j, new(big.Int))
+		}
+	}
+
+	_, _, err := A.pivot(A.vars.w(1), A.vars.z(1))
+	assert.True(t, errors.Is(err, ErrBadPivot))
+	assert.Equal(t, true, A.vars.w(1).isBasic(), "failed pivot leaves the basis alone")
+}
@@ -0,0 +1,196 @@
+package lemke
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Field is the arithmetic of the field tableau behind NewFieldSolver,
+// CheckBasis and FloatPivoting, with elements of type E.  The integer
+// tableau of Solve does not use it, it pivots fraction free in big.Int.
+// Operations return new values and leave their arguments alone.
+type Field[E any] interface {
+	// FromRat is the element for r, an error wrapping ErrField if the
+	// field has none.
+	FromRat(r *big.Rat) (E, error)
+	Zero() E
+	One() E
+	Add(a E, b E) E
+	Sub(a E, b E) E
+	Mul(a E, b E) E
+	// Quo is a / b for b not zero.
+	Quo(a E, b E) E
+	Neg(a E) E
+	IsZero(a E) bool
+}
+
+// OrderedField is a Field in which Lemke's algorithm can run: the ratio
+// test compares elements and the solution is turned back into
+// rationals.
+type OrderedField[E any] interface {
+	Field[E]
+	// Cmp is -1, 0 or 1 as a < b, a == b or a > b.
+	Cmp(a E, b E) int
+	// Rat is the rational value of a.
+	Rat(a E) *big.Rat
+}
+
+// RatField is exact arithmetic in big.Rat, the rationals.
+type RatField struct{}
+
+// FromRat copies r.
+func (RatField) FromRat(r *big.Rat) (*big.Rat, error) {
+	return new(big.Rat).Set(r), nil
+}
+
+func (RatField) Zero() *big.Rat             { return new(big.Rat) }
+func (RatField) One() *big.Rat              { return big.NewRat(1, 1) }
+func (RatField) Add(a, b *big.Rat) *big.Rat { return new(big.Rat).Add(a, b) }
+func (RatField) Sub(a, b *big.Rat) *big.Rat { return new(big.Rat).Sub(a, b) }
+func (RatField) Mul(a, b *big.Rat) *big.Rat { return new(big.Rat).Mul(a, b) }
+func (RatField) Quo(a, b *big.Rat) *big.Rat { return new(big.Rat).Quo(a, b) }
+func (RatField) Neg(a *big.Rat) *big.Rat    { return new(big.Rat).Neg(a) }
+func (RatField) IsZero(a *big.Rat) bool     { return a.Sign() == 0 }
+func (RatField) Cmp(a, b *big.Rat) int      { return a.Cmp(b) }
+func (RatField) Rat(a *big.Rat) *big.Rat    { return new(big.Rat).Set(a) }
+
+// FloatField is float64 arithmetic in which values closer than Tolerance,
+// relative to the larger one if that exceeds one, count as equal, like
+// Options.FloatPivoting does.  A zero Tolerance compares exactly.
+type FloatField struct {
+	Tolerance float64
+}
+
+// FromRat is the nearest float64 to r, an error if r is too large.
+func (F FloatField) FromRat(r *big.Rat) (float64, error) {
+	value, _ := r.Float64()
+	if math.IsInf(value, 0) {
+		return 0, fmt.Errorf("%w: %s overflows float64", ErrField, r.RatString())
+	}
+	return value, nil
+}
+
+func (FloatField) Zero() float64            { return 0 }
+func (FloatField) One() float64             { return 1 }
+func (FloatField) Add(a, b float64) float64 { return a + b }
+func (FloatField) Sub(a, b float64) float64 { return a - b }
+func (FloatField) Mul(a, b float64) float64 { return a * b }
+func (FloatField) Quo(a, b float64) float64 { return a / b }
+func (FloatField) Neg(a float64) float64    { return -a }
+func (F FloatField) IsZero(a float64) bool  { return F.Cmp(a, 0) == 0 }
+
+// Cmp treats a and b as equal if they are within the tolerance.
+func (F FloatField) Cmp(a, b float64) int {
+	if math.Abs(a-b) <= F.Tolerance*math.Max(1, math.Max(math.Abs(a), math.Abs(b))) {
+		return 0
+	}
+	if a < b {
+		return -1
+	}
+	return 1
+}
+
+// Rat is the exact value of a, nil if a is not finite.
+func (FloatField) Rat(a float64) *big.Rat {
+	return new(big.Rat).SetFloat64(a)
+}
+
+// DefaultPrime is 2^61 - 1, a prime that leaves little chance of a
+// determinant being divisible by it.
+const DefaultPrime = 1<<61 - 1
+
+// PrimeField is arithmetic modulo a prime p.  It cannot run Lemke's
+// algorithm, it has no order, but it tells fast whether columns are
+// independent, see CheckBasis.  Make it with NewPrimeField: the zero
+// value has no modulus, FromRat fails on it and the operations panic.
+type PrimeField struct {
+	p uint64
+}
+
+// NewPrimeField is the field of integers modulo p, an error wrapping
+// ErrField if p is not prime.
+func NewPrimeField(p uint64) (PrimeField, error) {
+	if !new(big.Int).SetUint64(p).ProbablyPrime(20) {
+		return PrimeField{}, fmt.Errorf("%w: %d is not prime", ErrField, p)
+	}
+	return PrimeField{p: p}, nil
+}
+
+// check panics on the zero PrimeField, which would divide by zero or
+// silently not reduce at all.
+func (F PrimeField) check() {
+	if F.p == 0 {
+		panic("lemke: PrimeField has no modulus, see NewPrimeField")
+	}
+}
+
+// P is the modulus.
+func (F PrimeField) P() uint64 {
+	return F.p
+}
+
+// FromRat is the numerator of r times the inverse of its denominator,
+// an error if p divides the denominator.
+func (F PrimeField) FromRat(r *big.Rat) (uint64, error) {
+	if F.p == 0 {
+		return 0, fmt.Errorf("%w: PrimeField has no modulus, see NewPrimeField", ErrField)
+	}
+	p := new(big.Int).SetUint64(F.p)
+	den := new(big.Int).Mod(r.Denom(), p)
+	if den.Sign() == 0 {
+		return 0, fmt.Errorf("%w: %d divides the denominator of %s", ErrField, F.p, r.RatString())
+	}
+	num := new(big.Int).Mod(r.Num(), p)
+	return F.Quo(num.Uint64(), den.Uint64()), nil
+}
+
+func (F PrimeField) Zero() uint64 { return 0 }
+func (F PrimeField) One() uint64  { return 1 }
+
+func (F PrimeField) Add(a, b uint64) uint64 {
+	F.check()
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 || sum >= F.p {
+		sum -= F.p
+	}
+	return sum
+}
+
+func (F PrimeField) Sub(a, b uint64) uint64 {
+	F.check()
+	diff, borrow := bits.Sub64(a, b, 0)
+	if borrow != 0 {
+		diff += F.p
+	}
+	return diff
+}
+
+func (F PrimeField) Mul(a, b uint64) uint64 {
+	F.check()
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, F.p)
+}
+
+// Quo multiplies with the inverse  b^(p-2)  of b.
+func (F PrimeField) Quo(a, b uint64) uint64 {
+	inverse := uint64(1)
+	for e := F.p - 2; e > 0; e >>= 1 {
+		if e&1 != 0 {
+			inverse = F.Mul(inverse, b)
+		}
+		b = F.Mul(b, b)
+	}
+	return F.Mul(a, inverse)
+}
+
+func (F PrimeField) Neg(a uint64) uint64 {
+	F.check()
+	if a == 0 {
+		return 0
+	}
+	return F.p - a
+}
+
+func (F PrimeField) IsZero(a uint64) bool { return a == 0 }
@@ -0,0 +1,88 @@
+package lemke
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrimeFieldArithmetic(t *testing.T) {
+
+	F, err := NewPrimeField(7)
+	assert.Nil(t, err)
+
+	assert.Equal(t, uint64(2), F.Add(5, 4))
+	assert.Equal(t, uint64(5), F.Sub(2, 4))
+	assert.Equal(t, uint64(6), F.Mul(3, 9%7))
+	assert.Equal(t, uint64(3), F.Neg(4))
+	assert.Equal(t, uint64(0), F.Neg(0))
+	for b := uint64(1); b < 7; b++ {
+		assert.Equal(t, uint64(1), F.Mul(F.Quo(1, b), b))
+	}
+
+	// -1/3 = -5 = 2
+	x, err := F.FromRat(big.NewRat(-1, 3))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), x)
+
+	_, err = F.FromRat(big.NewRat(1, 14))
+	assert.True(t, errors.Is(err, ErrField))
+}
+
+func TestPrimeFieldLargeModulus(t *testing.T) {
+
+	F, err := NewPrimeField(DefaultPrime)
+	assert.Nil(t, err)
+
+	// near the modulus sums and products must not overflow
+	a := uint64(DefaultPrime - 1)
+	assert.Equal(t, uint64(DefaultPrime-2), F.Add(a, a))
+	assert.Equal(t, uint64(1), F.Mul(a, a))
+	assert.Equal(t, uint64(1), F.Mul(F.Quo(1, 12345), 12345))
+
+	_, err = NewPrimeField(DefaultPrime + 2)
+	assert.True(t, errors.Is(err, ErrField))
+	_, err = NewPrimeField(1)
+	assert.True(t, errors.Is(err, ErrField))
+}
+
+func TestPrimeFieldZeroValue(t *testing.T) {
+
+	var F PrimeField
+	_, err := F.FromRat(big.NewRat(1, 2))
+	assert.True(t, errors.Is(err, ErrField))
+	assert.Panics(t, func() { F.Mul(2, 3) })
+	assert.Panics(t, func() { F.Add(2, 3) })
+
+	lcp := newTestLCP(t, ints2rats([]int{1, 0, 0, 1}), ints2rats([]int{-1, -1}))
+	err = CheckBasis[uint64](lcp, ints2rats([]int{1, 1}), []Variable{Z(1), Z(2)}, F)
+	assert.True(t, errors.Is(err, ErrField))
+}
+
+func TestFloatFieldTolerance(t *testing.T) {
+
+	F := FloatField{Tolerance: 1e-9}
+	assert.Equal(t, 0, F.Cmp(1e-10, 0))
+	assert.Equal(t, 1, F.Cmp(1e-8, 0))
+	assert.Equal(t, 0, F.Cmp(1e12, 1e12+1))
+	assert.Equal(t, -1, F.Cmp(1, 2))
+	assert.True(t, F.IsZero(-1e-12))
+
+	exact := FloatField{}
+	assert.False(t, exact.IsZero(1e-300))
+
+	_, err := F.FromRat(new(big.Rat).SetFrac(new(big.Int).Lsh(big.NewInt(1), 2000), big.NewInt(1)))
+	assert.True(t, errors.Is(err, ErrField))
+}
+
+func TestRatFieldLeavesArguments(t *testing.T) {
+
+	var F RatField
+	a, b := big.NewRat(1, 2), big.NewRat(1, 3)
+	assert.Equal(t, "5/6", F.Add(a, b).RatString())
+	assert.Equal(t, "3/2", F.Quo(a, b).RatString())
+	assert.Equal(t, "1/2", a.RatString())
+	assert.Equal(t, "1/3", b.RatString())
+}
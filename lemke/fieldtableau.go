@@ -0,0 +1,354 @@
+package lemke
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+/*
+ * fieldTableau
+ * ================================================================
+ * the tableau of  w = q + d z0 + Mz  in a Field.  With division at
+ * hand it stores the integer tableau divided by its determinant and
+ * remembers only the sign of the determinant, so nothing needs
+ * scaling and pivoting is an ordinary Gauss-Jordan step.
+ *
+ * tableau is not generic over the Field: its pivot divides exactly by
+ * the previous determinant, which needs the integers, and its storage
+ * kinds are ways of holding integers.  The two share the variables,
+ * the ratio test of lexminratio.go and the Solver that pivots them.
+ */
+type fieldTableau[E any] struct {
+	field   Field[E]
+	matrix  []E
+	ncols   int
+	nrows   int
+	vars    *tableauVariables
+	detSign int
+}
+
+func newFieldTableau[E any](lcp *LCP, d []*big.Rat, field Field[E]) (*fieldTableau[E], error) {
+
+	T := &fieldTableau[E]{
+		field:   field,
+		matrix:  make([]E, lcp.n*(lcp.n+2)),
+		ncols:   lcp.n + 2,
+		nrows:   lcp.n,
+		vars:    newTableauVariables(lcp.n),
+		detSign: -1,
+	}
+
+	/* same layout as createTableau divided by det = -1 */
+	neg := new(big.Rat)
+	for i := 0; i < T.nrows; i++ {
+		for j := 0; j < T.ncols; j++ {
+			switch {
+			case j == 0:
+				neg.Neg(d[i])
+			case j == T.rhsCol():
+				neg.Neg(lcp.q[i])
+			default:
+				neg.Neg(lcp.M(i, j-1))
+			}
+			value, err := field.FromRat(neg)
+			if err != nil {
+				return nil, err
+			}
+			T.set(i, j, value)
+		}
+	}
+	return T, nil
+}
+
+func (T *fieldTableau[E]) set(row int, col int, value E) {
+	T.matrix[row*T.ncols+col] = value
+}
+
+func (T *fieldTableau[E]) entry(row int, col int) E {
+	return T.matrix[row*T.ncols+col]
+}
+
+func (T *fieldTableau[E]) variables() *tableauVariables {
+	return T.vars
+}
+
+func (T *fieldTableau[E]) rhsCol() int {
+	return T.ncols - 1
+}
+
+func (T *fieldTableau[E]) negateCol(col int) {
+	for i := 0; i < T.nrows; i++ {
+		T.set(i, col, T.field.Neg(T.entry(i, col)))
+	}
+}
+
+/*
+ * ordinary Gauss-Jordan step on  A/det, which is what the integer
+ * pivot amounts to once both tableaus are divided by their determinant
+ */
+func (T *fieldTableau[E]) pivotMatrix(row int, col int) {
+
+	F := T.field
+	pivelt := T.entry(row, col)
+
+	for i := 0; i < T.nrows; i++ {
+		if i == row {
+			continue
+		}
+		factor := T.entry(i, col)
+		if F.IsZero(factor) {
+			continue
+		}
+		ratio := F.Quo(factor, pivelt)
+		for j := 0; j < T.ncols; j++ {
+			if j != col {
+				T.set(i, j, F.Sub(T.entry(i, j), F.Mul(ratio, T.entry(row, j))))
+			}
+		}
+		T.set(i, col, F.Neg(ratio))
+	}
+
+	for j := 0; j < T.ncols; j++ {
+		if j != col {
+			T.set(row, j, F.Quo(T.entry(row, j), pivelt))
+		}
+	}
+	T.set(row, col, F.Quo(F.One(), pivelt))
+
+	T.detSign = 1 // integer pivoting always leaves a positive determinant
+}
+
+func (T *fieldTableau[E]) basis() []Variable {
+	basis := make([]Variable, T.nrows)
+	for i := 0; i < T.nrows; i++ {
+		basis[i] = T.vars.fromRow(i).variable()
+	}
+	return basis
+}
+
+// factorize is tableau.factorize in the field: pivot until exactly the
+// variables in  basis  are basic, ErrBadBasis if their columns are
+// dependent.
+func (T *fieldTableau[E]) factorize(basis []Variable) error {
+
+	wanted, err := T.vars.wanted(basis)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range basis {
+
+		enter := T.vars.lookupVariable(v)
+		if enter.isBasic() {
+			continue
+		}
+
+		col := enter.col()
+		leaveRow := -1
+		for i := 0; i < T.nrows; i++ {
+			if !wanted[T.vars.fromRow(i).idx] && !T.field.IsZero(T.entry(i, col)) {
+				leaveRow = i
+				break
+			}
+		}
+
+		if leaveRow < 0 {
+			return fmt.Errorf("%w: singular, %v depends on the other basic columns", ErrBadBasis, v)
+		}
+
+		row, col := T.vars.swap(enter, T.vars.fromRow(leaveRow))
+		T.pivotMatrix(row, col)
+	}
+	return nil
+}
+
+// orderedTableau is a fieldTableau that Lemke's algorithm can run on.
+type orderedTableau[E any] struct {
+	*fieldTableau[E]
+	order OrderedField[E]
+}
+
+func newOrderedTableau[E any](lcp *LCP, d []*big.Rat, field OrderedField[E]) (*orderedTableau[E], error) {
+	T, err := newFieldTableau[E](lcp, d, field)
+	if err != nil {
+		return nil, err
+	}
+	return &orderedTableau[E]{T, field}, nil
+}
+
+// sign of the integer tableau entry
+func (T *orderedTableau[E]) sign(row int, col int) int {
+	return T.order.Cmp(T.entry(row, col), T.field.Zero()) * T.detSign
+}
+
+/*
+ * sign of  A[a,testcol] / A[a,col] - A[b,testcol] / A[b,col]
+ * both products scale with det^2 so the sign of det does not matter
+ */
+func (T *orderedTableau[E]) ratioTest(rowA int, rowB int, colA int, colB int) int {
+	a := T.field.Mul(T.entry(rowA, colB), T.entry(rowB, colA))
+	b := T.field.Mul(T.entry(rowB, colB), T.entry(rowA, colA))
+	return T.order.Cmp(a, b)
+}
+
+func (T *orderedTableau[E]) pivot(leave *tableauVariable, enter *tableauVariable) (int, int, error) {
+
+	if !leave.isBasic() || enter.isBasic() {
+		return 0, 0, fmt.Errorf("%w: %v cannot replace %v", ErrBadPivot, enter, leave)
+	}
+
+	if T.sign(leave.row(), enter.col()) == 0 {
+		return 0, 0, fmt.Errorf("%w: %v cannot replace %v on a zero", ErrBadPivot, enter, leave)
+	}
+
+	row, col := T.vars.swap(enter, leave)
+	T.pivotMatrix(row, col)
+	return row, col, nil
+}
+
+// varValue is the value of any variable, zero if cobasic.  Dividing by
+// the determinant left nothing to scale.
+func (T *orderedTableau[E]) varValue(v *tableauVariable) *big.Rat {
+	if !v.isBasic() {
+		return new(big.Rat)
+	}
+	return T.order.Rat(T.entry(v.row(), T.rhsCol()))
+}
+
+func (T *orderedTableau[E]) result(status Status, pivots int) *Result {
+
+	n := T.vars.n
+	res := &Result{
+		Status: status,
+		Z:      make([]*big.Rat, n),
+		W:      make([]*big.Rat, n),
+		Z0:     T.varValue(T.vars.z(0)),
+		Basis:  T.basis(),
+		Pivots: pivots,
+	}
+
+	for i := 0; i < n; i++ {
+		res.Z[i] = T.varValue(T.vars.z(i + 1))
+		res.W[i] = T.varValue(T.vars.w(i + 1))
+	}
+	return res
+}
+
+// ray is the integer ray divided by |det|, so  enter  grows by one
+func (T *orderedTableau[E]) ray(enter *tableauVariable) []*big.Rat {
+
+	n := T.vars.n
+	dir := make([]*big.Rat, n+1)
+	for i := 0; i <= n; i++ {
+		dir[i] = new(big.Rat)
+	}
+
+	if enter.isZ() {
+		dir[enter.idx].SetInt64(1)
+	}
+
+	col := enter.col()
+	for i := 0; i < n; i++ {
+		basic := T.vars.fromRow(i)
+		if basic.isZ() {
+			dir[basic.idx] = T.order.Rat(T.field.Neg(T.entry(i, col)))
+		}
+	}
+	return dir
+}
+
+// pivotStep has no determinant and no snapshot, there is no integer
+// tableau to take them from.
+func (T *orderedTableau[E]) pivotStep(count int, enter *tableauVariable, leave *tableauVariable, row int, col int, snapshot bool) *PivotStep {
+	return &PivotStep{
+		Count: count,
+		Enter: enter.variable(),
+		Leave: leave.variable(),
+		Row:   row,
+		Col:   col,
+		Z0:    T.varValue(T.vars.z(0)),
+	}
+}
+
+// snapshot is nil, there is no integer tableau to copy.
+func (T *orderedTableau[E]) snapshot() *Snapshot {
+	return nil
+}
+
+// NewFieldSolver is NewSolver pivoting in field instead of exactly in
+// integers, e.g. in FloatField for speed or RatField to check the
+// integer tableau, which itself stays in big.Int.  Options.Storage,
+// Workers and FloatPivoting do not apply, and neither WarmStart nor
+// BlockSize is supported.
+func NewFieldSolver[E any](lcp *LCP, d []*big.Rat, field OrderedField[E], opts *Options) (*Solver, error) {
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	rule, err := opts.pivotRule(lcp.n)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Method != Lemke || opts.WarmStart != nil || opts.BlockSize > 0 {
+		return nil, fmt.Errorf("%w: a field tableau only runs Lemke's method from a cold start", ErrUnsupported)
+	}
+
+	if err := checkInputs(lcp.q, d); err != nil {
+		return nil, err
+	}
+
+	T, err := newOrderedTableau(lcp, d, field)
+	if err != nil {
+		return nil, err
+	}
+
+	// z0 enters the basis to obtain lex-feasible solution
+	enter := T.vars.z(0)
+	leave, z0leave, ties, err := minratio(T, enter, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	// now give the entering q-col its correct sign
+	T.negateCol(T.rhsCol())
+
+	return newSolver(T, opts, enter, leave, z0leave, ties), nil
+}
+
+// SolveField runs Lemke's algorithm in field, see NewFieldSolver.  How
+// exact the Result is depends on the field.
+func SolveField[E any](lcp *LCP, d []*big.Rat, field OrderedField[E], opts *Options) (*Result, error) {
+	return SolveFieldContext(context.Background(), lcp, d, field, opts)
+}
+
+// SolveFieldContext is SolveField but stops with a *CanceledError once
+// ctx is done.
+func SolveFieldContext[E any](ctx context.Context, lcp *LCP, d []*big.Rat, field OrderedField[E], opts *Options) (*Result, error) {
+
+	s, err := NewFieldSolver(lcp, d, field, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.solve(ctx)
+}
+
+// CheckBasis tells whether the columns of the variables in basis are
+// independent in field, i.e. whether they are a basis of the tableau of
+// the LCP with covering vector d.  In a PrimeField a nil error proves
+// them independent over the rationals as well, and fast; ErrBadBasis
+// there may be a false alarm if the prime divides their determinant.
+func CheckBasis[E any](lcp *LCP, d []*big.Rat, basis []Variable, field Field[E]) error {
+
+	if len(d) != lcp.n {
+		return fmt.Errorf("%w: covering vector has %d entries but q has %d", ErrDimension, len(d), lcp.n)
+	}
+
+	T, err := newFieldTableau(lcp, d, field)
+	if err != nil {
+		return err
+	}
+	return T.factorize(basis)
+}
@@ -0,0 +1,89 @@
+package lemke
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatFieldMatchesIntegerTableau(t *testing.T) {
+
+	for seed := int64(1); seed <= 10; seed++ {
+		lcp, d := randomTestLCP(t, 8, seed)
+
+		expected, err := SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+
+		actual, err := SolveField[*big.Rat](lcp, d, RatField{}, nil)
+		assert.Nil(t, err)
+		assertSameResult(t, expected, actual)
+	}
+}
+
+func TestFloatFieldMatchesIntegerTableau(t *testing.T) {
+
+	for seed := int64(1); seed <= 10; seed++ {
+		lcp, d := bimatrixTestLCP(t, 4, 100, seed)
+
+		expected, err := SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+
+		actual, err := SolveField[float64](lcp, d, FloatField{Tolerance: DefaultTolerance}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, expected.Basis, actual.Basis)
+		assert.Equal(t, expected.Pivots, actual.Pivots)
+
+		z := make([]float64, len(actual.Z))
+		for i, value := range actual.Z {
+			z[i], _ = value.Float64()
+		}
+		report, err := VerifyFloat(lcp, z, 1e-9)
+		assert.Nil(t, err)
+		assert.True(t, report.OK())
+	}
+}
+
+func TestFieldSolverRayTermination(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{-1}), ints2rats([]int{-1}))
+	expected, err := SolveResult(lcp, ints2rats([]int{1}), nil)
+	assert.True(t, errors.Is(err, ErrRayTermination))
+
+	actual, err := SolveField[*big.Rat](lcp, ints2rats([]int{1}), RatField{}, nil)
+	assert.True(t, errors.Is(err, ErrRayTermination))
+	assert.Equal(t, RayTermination, actual.Status)
+	assert.Equal(t, solutionKey(expected.Ray), solutionKey(actual.Ray))
+}
+
+func TestFieldSolverUnsupported(t *testing.T) {
+
+	lcp, d := randomTestLCP(t, 3, 1)
+	_, err := NewFieldSolver[*big.Rat](lcp, d, RatField{}, &Options{BlockSize: 1})
+	assert.True(t, errors.Is(err, ErrUnsupported))
+}
+
+func TestCheckBasis(t *testing.T) {
+
+	lcp, d := randomTestLCP(t, 6, 3)
+	res, err := SolveResult(lcp, d, nil)
+	assert.Nil(t, err)
+
+	F, err := NewPrimeField(DefaultPrime)
+	assert.Nil(t, err)
+	assert.Nil(t, CheckBasis[uint64](lcp, d, res.Basis, F))
+	assert.Nil(t, CheckBasis[*big.Rat](lcp, d, res.Basis, RatField{}))
+
+	// with d = (1, 0) the columns of z0 and w1 are the same
+	M := ints2rats([]int{0, 1, 1, 0})
+	q := ints2rats([]int{-1, -1})
+	small := newTestLCP(t, M, q)
+	err = CheckBasis[uint64](small, ints2rats([]int{1, 1}), []Variable{Z(0), W(1)}, F)
+	assert.Nil(t, err)
+	err = CheckBasis[uint64](small, ints2rats([]int{1, 0}), []Variable{Z(0), W(1)}, F)
+	assert.True(t, errors.Is(err, ErrBadBasis))
+
+	err = CheckBasis[uint64](small, ints2rats([]int{1, 1}), []Variable{Z(1)}, F)
+	assert.True(t, errors.Is(err, ErrBadBasis))
+}
@@ -0,0 +1,314 @@
+package lemke
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+)
+
+// DefaultTolerance is used by float pivoting when Options.Tolerance is 0.
+const DefaultTolerance = 1e-9
+
+// floatTableau is the tableau in FloatField used to find a complementary
+// basis quickly.  Signs and ratios seen by lexminratio are the same as
+// for the integer tableau.  Column scale factors are not needed: scaling
+// a column by a positive factor changes neither signs nor the order of
+// ratios.
+type floatTableau = orderedTableau[float64]
+
+// lostPrecision reports whether pivoting has overflowed somewhere.
+func lostPrecision(F *floatTableau) bool {
+	for _, value := range F.matrix {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ * floatLemke
+ * ================================================================
+ * Lemke's algorithm on the float tableau, same steps as the exact run.
+ * @return the basis once z0 leaves, Solved, or once Options.MaxPivots
+ * pivots are made, PivotLimit.  Any other outcome is an error and the
+ * caller falls back to exact pivoting.
+ */
+func floatLemke(ctx context.Context, lcp *LCP, d []*big.Rat, opts *Options) ([]Variable, int, Status, error) {
+
+	tol := opts.Tolerance
+	if tol == 0 {
+		tol = DefaultTolerance
+	}
+
+	F, err := newOrderedTableau[float64](lcp, d, FloatField{Tolerance: tol})
+	if err != nil {
+		return nil, 0, Running, err
+	}
+	rule, _ := opts.pivotRule(lcp.n)
+
+	enter := F.vars.z(0)
+	leave, z0leave, _, err := minratio(F, enter, rule)
+	if err != nil {
+		return nil, 0, Running, err
+	}
+
+	F.negateCol(F.rhsCol())
+
+	pivotCount := 1
+	for {
+
+		if ctx.Err() != nil {
+			return F.basis(), pivotCount - 1, Canceled, ctx.Err()
+		}
+
+		_, _, err = F.pivot(leave, enter)
+		if err != nil {
+			return nil, pivotCount, Running, err
+		}
+		if lostPrecision(F) {
+			return nil, pivotCount, Running, errors.New("lemke: float pivoting lost all precision")
+		}
+
+		if z0leave {
+			return F.basis(), pivotCount, Solved, nil
+		}
+
+		enter, err = leave.complement()
+		if err != nil {
+			return nil, pivotCount, Running, err
+		}
+
+		leave, z0leave, _, err = minratio(F, enter, rule)
+		if err != nil {
+			return nil, pivotCount, Running, err
+		}
+
+		if pivotCount == opts.MaxPivots {
+			return F.basis(), pivotCount, PivotLimit, nil
+		}
+
+		pivotCount++
+	}
+}
+
+/*
+ * solveFloat
+ * ================================================================
+ * run Lemke in floating point, then compute the values of the basis
+ * found there exactly from the basic system alone, see basicResult.
+ * The result is only accepted if that basis is feasible, and
+ * complementary if Solved.
+ * @return nil, nil if the float basis was rejected
+ */
+func solveFloat(ctx context.Context, lcp *LCP, d []*big.Rat, opts *Options) (*Result, error) {
+
+	basis, pivots, status, err := floatLemke(ctx, lcp, d, opts)
+
+	if ctx.Err() != nil {
+		// best effort to hand back the basis reached so far
+		res, _ := basicResult(lcp, d, basis, Canceled, pivots)
+		if res == nil {
+			res, _ = basicResult(lcp, d, allW(lcp.n), Canceled, 0)
+		}
+		return res, &CanceledError{Result: res, Err: ctx.Err()}
+	}
+
+	if err != nil {
+		return nil, nil
+	}
+
+	res, feasible := basicResult(lcp, d, basis, status, pivots)
+	if res == nil || !feasible {
+		return nil, nil
+	}
+	if status == Solved && !complementary(basis) {
+		return nil, nil
+	}
+	return res, nil
+}
+
+// allW is the basis of a cold start.
+func allW(n int) []Variable {
+	basis := make([]Variable, n)
+	for i := range basis {
+		basis[i] = W(i + 1)
+	}
+	return basis
+}
+
+// complementary reports whether z0 is not in basis and exactly one of
+// z(i), w(i) is for every i.
+func complementary(basis []Variable) bool {
+	seen := make(map[int]bool, len(basis))
+	for _, v := range basis {
+		if v == Z(0) || seen[v.Index()] {
+			return false
+		}
+		seen[v.Index()] = true
+	}
+	return true
+}
+
+/*
+ * basicResult
+ * ================================================================
+ * the Result for  basis  computed exactly without pivoting a tableau.
+ * In  w = Mz + q + d z0  the columns of basic w's are unit vectors,
+ * so only the rows  K  of the cobasic w's need solving, for the basic
+ * z's  V  (z0 included):
+ *     M_KV z_V + d_K z0 = -q_K
+ * by fraction-free elimination, and the basic w's follow.  Each row
+ * is scaled to integers first, so with  y = det z  all of it is
+ * integer arithmetic until the values are divided by  det.
+ * @return nil if basis is none, and whether all values are >= 0
+ */
+func basicResult(lcp *LCP, d []*big.Rat, basis []Variable, status Status, pivots int) (*Result, bool) {
+
+	n := lcp.n
+	wanted, err := newTableauVariables(n).wanted(basis)
+	if err != nil {
+		return nil, false
+	}
+
+	var zs []int
+	for i := 0; i <= n; i++ {
+		if wanted[i] {
+			zs = append(zs, i)
+		}
+	}
+
+	// row i scaled to integers: coefs[i][l] for zs[l], then q_i
+	coefs := make([][]*big.Int, n)
+	scales := make([]*big.Int, n)
+	for i := range coefs {
+		entry := func(l int) *big.Rat {
+			switch {
+			case l == len(zs):
+				return lcp.q[i]
+			case zs[l] == 0:
+				return d[i]
+			}
+			return lcp.M(i, zs[l]-1)
+		}
+		scales[i] = computeScaleFactor(len(zs)+1, entry)
+		coefs[i] = make([]*big.Int, len(zs)+1)
+		for l := range coefs[i] {
+			coefs[i][l] = scaleRat(entry(l), scales[i])
+		}
+	}
+
+	var A [][]*big.Int
+	var b []*big.Int
+	for i := 0; i < n; i++ {
+		if !wanted[i+1+n] {
+			row := make([]*big.Int, len(zs))
+			for l := range row {
+				row[l] = new(big.Int).Set(coefs[i][l])
+			}
+			A = append(A, row)
+			b = append(b, new(big.Int).Neg(coefs[i][len(zs)]))
+		}
+	}
+
+	y, det, ok := solveFractionFree(A, b)
+	if !ok {
+		return nil, false
+	}
+
+	feasible := true
+	z := make([]*big.Rat, n+1)
+	for i := range z {
+		z[i] = new(big.Rat)
+	}
+	for l, v := range zs {
+		z[v].SetFrac(y[l], det)
+		feasible = feasible && z[v].Sign() >= 0
+	}
+
+	res := &Result{
+		Status: status,
+		Z:      z[1:],
+		W:      make([]*big.Rat, n),
+		Z0:     z[0],
+		Basis:  append([]Variable{}, basis...),
+		Pivots: pivots,
+	}
+
+	// scale_i det w_i = scale_i det q_i + sum_l coefs[i][l] y_l
+	tmp := new(big.Int)
+	for i := 0; i < n; i++ {
+		res.W[i] = new(big.Rat)
+		if !wanted[i+1+n] {
+			continue
+		}
+		sum := new(big.Int).Mul(det, coefs[i][len(zs)])
+		for l := range zs {
+			sum.Add(sum, tmp.Mul(coefs[i][l], y[l]))
+		}
+		res.W[i].SetFrac(sum, tmp.Mul(det, scales[i]))
+		feasible = feasible && res.W[i].Sign() >= 0
+	}
+	return res, feasible
+}
+
+// scaleRat is the integer  rat * scale, scale a multiple of its
+// denominator.
+func scaleRat(rat *big.Rat, scale *big.Int) *big.Int {
+	value := new(big.Int).Mul(rat.Num(), scale)
+	return value.Quo(value, rat.Denom())
+}
+
+/*
+ * solveFractionFree
+ * ================================================================
+ * solve the square integer system  Ax = b  by Bareiss elimination:
+ * after eliminating column k every entry below is a minor of  (A b)
+ * and the division by the previous pivot is exact.  The last pivot
+ * is  det = +-det A, so back substitution for  y = det x  stays in
+ * the integers too.  A and b are overwritten.
+ * @return y and det, false if A is singular
+ */
+func solveFractionFree(A [][]*big.Int, b []*big.Int) ([]*big.Int, *big.Int, bool) {
+
+	n := len(A)
+	for i := range A {
+		A[i] = append(A[i], b[i])
+	}
+
+	prev := big.NewInt(1)
+	tmp := new(big.Int)
+	for k := 0; k < n; k++ {
+
+		pivot := k
+		for pivot < n && A[pivot][k].Sign() == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, nil, false
+		}
+		A[k], A[pivot] = A[pivot], A[k]
+
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j <= n; j++ {
+				A[i][j].Mul(A[i][j], A[k][k])
+				A[i][j].Sub(A[i][j], tmp.Mul(A[i][k], A[k][j]))
+				A[i][j].Quo(A[i][j], prev)
+			}
+			A[i][k].SetInt64(0)
+		}
+		prev = A[k][k]
+	}
+
+	det := prev
+	y := make([]*big.Int, n)
+	for i := n - 1; i >= 0; i-- {
+		y[i] = new(big.Int).Mul(det, A[i][n])
+		for j := i + 1; j < n; j++ {
+			y[i].Sub(y[i], tmp.Mul(A[i][j], y[j]))
+		}
+		y[i].Quo(y[i], A[i][i])
+	}
+	return y, det, true
+}
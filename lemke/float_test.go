@@ -0,0 +1,124 @@
+package lemke
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// diagonally dominant so M is a P-matrix and the solution unique
+func randomTestLCP(t *testing.T, n int, seed int64) (*LCP, []*big.Rat) {
+
+	r := rand.New(rand.NewSource(seed))
+	M := make([]*big.Rat, n*n)
+	q := make([]*big.Rat, n)
+	d := make([]*big.Rat, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			M[i*n+j] = big.NewRat(int64(r.Intn(19)-9), int64(r.Intn(3)+1))
+		}
+		M[i*n+i] = big.NewRat(int64(10*n), 1)
+		q[i] = big.NewRat(int64(r.Intn(21)-15), 1)
+		d[i] = big.NewRat(1, 1)
+	}
+	q[0] = big.NewRat(-1, 1)
+	return newTestLCP(t, M, q), d
+}
+
+func TestFloatPivotingMatchesExact(t *testing.T) {
+
+	for seed := int64(1); seed <= 5; seed++ {
+		lcp, d := randomTestLCP(t, 25, seed)
+
+		exact, err := SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+
+		fast, err := SolveResult(lcp, d, &Options{FloatPivoting: true})
+		assert.Nil(t, err)
+		assert.Equal(t, Solved, fast.Status)
+		assert.Equal(t, false, fast.FloatFallback)
+		assert.Equal(t, exact.Pivots, fast.Pivots)
+		for i := range exact.Z {
+			assert.Equal(t, 0, exact.Z[i].Cmp(fast.Z[i]), "z%d", i+1)
+		}
+	}
+}
+
+func TestFloatPivotingOnDegenerateLCP(t *testing.T) {
+
+	M := ints2rats([]int{0, -1, 2, 2, 0, -2, -1, 1, 0})
+	q := ints2rats([]int{-3, 6, -1})
+	d := ints2rats([]int{1, 1, 1})
+
+	res, err := SolveResult(newTestLCP(t, M, q), d, &Options{FloatPivoting: true})
+	assert.Nil(t, err)
+	assert.Equal(t, false, res.FloatFallback)
+	assert.Equal(t, "0", res.Z[0].RatString())
+	assert.Equal(t, "1", res.Z[1].RatString())
+	assert.Equal(t, "3", res.Z[2].RatString())
+}
+
+func TestFloatPivotingFallsBack(t *testing.T) {
+
+	M := ints2rats([]int{2, 1, 1, 3})
+	q := ints2rats([]int{-1, -1})
+	d := ints2rats([]int{2, 1})
+
+	// everything is zero at this tolerance so the float run cannot pivot
+	res, err := SolveResult(newTestLCP(t, M, q), d, &Options{FloatPivoting: true, Tolerance: 1e6})
+	assert.Nil(t, err)
+	assert.Equal(t, true, res.FloatFallback)
+	assert.Equal(t, "2/5", res.Z[0].RatString())
+	assert.Equal(t, "1/5", res.Z[1].RatString())
+}
+
+func TestFloatPivotingPivotLimit(t *testing.T) {
+
+	lcp, d := randomTestLCP(t, 25, 1)
+
+	exact, err := SolveResult(lcp, d, &Options{MaxPivots: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, PivotLimit, exact.Status)
+
+	fast, err := SolveResult(lcp, d, &Options{FloatPivoting: true, MaxPivots: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, PivotLimit, fast.Status)
+	assert.Equal(t, false, fast.FloatFallback)
+	assert.Equal(t, exact.Basis, fast.Basis)
+	assert.Equal(t, solutionKey(exact.Z), solutionKey(fast.Z))
+	assert.Equal(t, solutionKey(exact.W), solutionKey(fast.W))
+	assert.Equal(t, 0, exact.Z0.Cmp(fast.Z0))
+}
+
+func TestBasicResult(t *testing.T) {
+
+	lcp, d := randomTestLCP(t, 10, 4)
+	exact, err := SolveResult(lcp, d, nil)
+	assert.Nil(t, err)
+
+	res, feasible := basicResult(lcp, d, exact.Basis, Solved, exact.Pivots)
+	assert.True(t, feasible)
+	assert.Equal(t, solutionKey(exact.Z), solutionKey(res.Z))
+	assert.Equal(t, solutionKey(exact.W), solutionKey(res.W))
+
+	// z1 and w1 both basic is no basis of a complementary solution, and
+	// z1 twice no basis at all
+	basis := append([]Variable{}, exact.Basis...)
+	for i, v := range basis {
+		if v.Index() == 2 {
+			basis[i] = Z(1)
+		}
+	}
+	res, _ = basicResult(lcp, d, basis, Solved, 0)
+	assert.False(t, res != nil && complementary(basis))
+
+	y, det, ok := solveFractionFree([][]*big.Int{{big.NewInt(0), big.NewInt(2)}, {big.NewInt(3), big.NewInt(1)}}, []*big.Int{big.NewInt(4), big.NewInt(5)})
+	assert.True(t, ok)
+	assert.Equal(t, "1", new(big.Rat).SetFrac(y[0], det).RatString())
+	assert.Equal(t, "2", new(big.Rat).SetFrac(y[1], det).RatString())
+
+	_, _, ok = solveFractionFree([][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(2), big.NewInt(4)}}, []*big.Int{big.NewInt(1), big.NewInt(1)})
+	assert.False(t, ok)
+}
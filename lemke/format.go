@@ -0,0 +1,291 @@
+package lemke
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+/*
+ * JSON format
+ * ================================================================
+ *   {
+ *     "n": 2,
+ *     "M": [["2", "1"], ["1", "3"]],
+ *     "q": ["-1", "-1/2"],
+ *     "d": ["1", "1"]
+ *   }
+ * M is given row by row, d may be left out.  Entries are exact: either
+ * strings accepted by big.Rat.SetString such as "-3", "1/3" or "0.25",
+ * or JSON numbers, which are read from their decimal text and not via
+ * float64.  Written files always use strings in lowest terms.
+ */
+
+type lcpJSON struct {
+	N int         `json:"n"`
+	M [][]jsonRat `json:"M"`
+	Q []jsonRat   `json:"q"`
+	D []jsonRat   `json:"d,omitempty"`
+}
+
+type jsonRat struct {
+	*big.Rat
+}
+
+func (r jsonRat) MarshalJSON() ([]byte, error) {
+	if r.Rat == nil {
+		return nil, fmt.Errorf("%w: nil entry", ErrFormat)
+	}
+	return json.Marshal(r.RatString())
+}
+
+func (r *jsonRat) UnmarshalJSON(data []byte) error {
+
+	var text string
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("%w: %s is not a string", ErrFormat, data)
+		}
+	} else {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("%w: %s is not a number", ErrFormat, data)
+		}
+		text = number.String()
+	}
+
+	value, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return fmt.Errorf("%w: %s is not a rational", ErrFormat, data)
+	}
+	r.Rat = value
+	return nil
+}
+
+// ReadJSON reads an LCP and its covering vector d, nil if the file has
+// none, in the JSON format described above.
+func ReadJSON(r io.Reader) (*LCP, []*big.Rat, error) {
+
+	var file lcpJSON
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+
+	if file.N != len(file.Q) || len(file.M) != file.N {
+		return nil, nil, fmt.Errorf("%w: n = %d but M has %d rows and q %d entries", ErrDimension, file.N, len(file.M), len(file.Q))
+	}
+
+	M := make([]*big.Rat, 0, file.N*file.N)
+	for i, row := range file.M {
+		if len(row) != file.N {
+			return nil, nil, fmt.Errorf("%w: row %d of M has %d entries, not %d", ErrDimension, i+1, len(row), file.N)
+		}
+		M = append(M, rats(row)...)
+	}
+
+	lcp, err := NewLCP(M, rats(file.Q))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if file.D != nil && len(file.D) != file.N {
+		return nil, nil, fmt.Errorf("%w: d has %d entries, not %d", ErrDimension, len(file.D), file.N)
+	}
+	return lcp, rats(file.D), nil
+}
+
+// WriteJSON writes the LCP and d, which may be nil, in the JSON format.
+func WriteJSON(w io.Writer, lcp *LCP, d []*big.Rat) error {
+
+	file := lcpJSON{
+		N: lcp.n,
+		M: make([][]jsonRat, lcp.n),
+		Q: jsonRats(lcp.q),
+		D: jsonRats(d),
+	}
+	for i := 0; i < lcp.n; i++ {
+		file.M[i] = jsonRats(lcp.m[i*lcp.n : (i+1)*lcp.n])
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(&file)
+}
+
+func rats(values []jsonRat) []*big.Rat {
+	if values == nil {
+		return nil
+	}
+	result := make([]*big.Rat, len(values))
+	for i, value := range values {
+		result[i] = value.Rat
+	}
+	return result
+}
+
+func jsonRats(values []*big.Rat) []jsonRat {
+	if values == nil {
+		return nil
+	}
+	result := make([]jsonRat, len(values))
+	for i, value := range values {
+		result[i] = jsonRat{value}
+	}
+	return result
+}
+
+/*
+ * plain text format
+ * ================================================================
+ *   # comments run to the end of the line
+ *   2
+ *   2   1
+ *   1   3
+ *   -1  -1/2
+ *   1   1
+ * n, then the n x n entries of M row by row, then the n entries of q
+ * and optionally the n entries of d.  Entries are separated by any
+ * white space, line breaks do not matter, and are rationals as for
+ * big.Rat.SetString.
+ */
+
+// ReadText reads an LCP and its covering vector d, nil if the file has
+// none, in the plain text format described above.
+func ReadText(r io.Reader) (*LCP, []*big.Rat, error) {
+
+	var tokens []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if hash := strings.IndexByte(line, '#'); hash >= 0 {
+			line = line[:hash]
+		}
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("%w: empty", ErrFormat)
+	}
+
+	n, err := strconv.Atoi(tokens[0])
+	if err != nil || n < 1 {
+		return nil, nil, fmt.Errorf("%w: %q is not a size", ErrFormat, tokens[0])
+	}
+
+	values := make([]*big.Rat, len(tokens)-1)
+	for i, token := range tokens[1:] {
+		value, ok := new(big.Rat).SetString(token)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %q is not a rational", ErrFormat, token)
+		}
+		values[i] = value
+	}
+
+	var d []*big.Rat
+	switch len(values) {
+	case n*n + n:
+	case n*n + 2*n:
+		d = values[n*n+n:]
+	default:
+		return nil, nil, fmt.Errorf("%w: %d entries do not make M, q and d of size %d", ErrDimension, len(values), n)
+	}
+
+	lcp, err := NewLCP(values[:n*n], values[n*n:n*n+n])
+	if err != nil {
+		return nil, nil, err
+	}
+	return lcp, d, nil
+}
+
+// WriteText writes the LCP and d, which may be nil, in the plain text
+// format.
+func WriteText(w io.Writer, lcp *LCP, d []*big.Rat) error {
+
+	buf := bufio.NewWriter(w)
+	fmt.Fprintln(buf, "# n, M, q, d")
+	fmt.Fprintln(buf, lcp.n)
+	for i := 0; i < lcp.n; i++ {
+		writeRow(buf, lcp.m[i*lcp.n:(i+1)*lcp.n])
+	}
+	writeRow(buf, lcp.q)
+	if d != nil {
+		writeRow(buf, d)
+	}
+	return buf.Flush()
+}
+
+func writeRow(w io.Writer, values []*big.Rat) {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = value.RatString()
+	}
+	fmt.Fprintln(w, strings.Join(strs, " "))
+}
+
+// ReadLCP reads either format, JSON if the first character that is not
+// white space is '{'.
+func ReadLCP(r io.Reader) (*LCP, []*big.Rat, error) {
+
+	buf := bufio.NewReader(r)
+	for {
+		c, _, err := buf.ReadRune()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: empty", ErrFormat)
+		}
+		if !strings.ContainsRune(" \t\r\n", c) {
+			buf.UnreadRune()
+			if c == '{' {
+				return ReadJSON(buf)
+			}
+			return ReadText(buf)
+		}
+	}
+}
+
+// WriteGAMS writes the LCP as a mixed complementarity problem in GAMS,
+// ready for the PATH solver: z >= 0 is complementary to the equation
+// Mz + q >= 0.  Data is given as assignments of exact fractions since
+// GAMS tables only take decimals; GAMS itself computes in double.
+func WriteGAMS(w io.Writer, lcp *LCP) error {
+
+	buf := bufio.NewWriter(w)
+	fmt.Fprintln(buf, "* LCP: z >= 0, Mz + q >= 0, z'(Mz + q) = 0")
+	fmt.Fprintf(buf, "Set i / 1*%d /;\n", lcp.n)
+	fmt.Fprintln(buf, "Alias (i, j);")
+	fmt.Fprintln(buf, "Parameter M(i,j), q(i);")
+	for i := 0; i < lcp.n; i++ {
+		for j := 0; j < lcp.n; j++ {
+			if value := lcp.M(i, j); value.Sign() != 0 {
+				fmt.Fprintf(buf, "M('%d','%d') = %s;\n", i+1, j+1, gamsRat(value))
+			}
+		}
+	}
+	for i := 0; i < lcp.n; i++ {
+		if value := lcp.Q(i); value.Sign() != 0 {
+			fmt.Fprintf(buf, "q('%d') = %s;\n", i+1, gamsRat(value))
+		}
+	}
+	fmt.Fprintln(buf, "Positive Variable z(i);")
+	fmt.Fprintln(buf, "Equation f(i);")
+	fmt.Fprintln(buf, "f(i).. sum(j, M(i,j)*z(j)) + q(i) =g= 0;")
+	fmt.Fprintln(buf, "Model lcp / f.z /;")
+	fmt.Fprintln(buf, "Solve lcp using mcp;")
+	fmt.Fprintln(buf, "Display z.l;")
+	return buf.Flush()
+}
+
+// gamsRat is  a  or  (a/b)  so that negative fractions parse as intended
+func gamsRat(value *big.Rat) string {
+	if value.IsInt() {
+		return value.RatString()
+	}
+	return "(" + value.RatString() + ")"
+}
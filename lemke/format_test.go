@@ -0,0 +1,119 @@
+package lemke
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadJSON(t *testing.T) {
+
+	lcp, d, err := ReadLCP(strings.NewReader(`
+		{"n": 2, "M": [["2", 1], ["1", "3"]], "q": [-1, "-1/2"], "d": ["1", 0.5]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, lcp.N())
+	assert.Equal(t, "2", lcp.M(0, 0).RatString())
+	assert.Equal(t, "1", lcp.M(0, 1).RatString())
+	assert.Equal(t, "-1/2", lcp.Q(1).RatString())
+	assert.Equal(t, "1/2", d[1].RatString())
+}
+
+func TestReadText(t *testing.T) {
+
+	lcp, d, err := ReadLCP(strings.NewReader(`
+		# a comment
+		2
+		2 1    # first row
+		1 3
+		-1 -1/2`))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, lcp.N())
+	assert.Equal(t, "3", lcp.M(1, 1).RatString())
+	assert.Equal(t, "-1/2", lcp.Q(1).RatString())
+	assert.Nil(t, d)
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+
+	M := ints2rats([]int{2, 1, 1, 3})
+	M[1].SetFrac64(-1, 3)
+	q := ints2rats([]int{-1, -1})
+	d := ints2rats([]int{2, 1})
+	lcp := newTestLCP(t, M, q)
+
+	for _, write := range []func(*bytes.Buffer) error{
+		func(buf *bytes.Buffer) error { return WriteJSON(buf, lcp, d) },
+		func(buf *bytes.Buffer) error { return WriteText(buf, lcp, d) },
+	} {
+		var buf bytes.Buffer
+		assert.Nil(t, write(&buf))
+
+		read, readD, err := ReadLCP(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, solutionKey(lcp.m), solutionKey(read.m))
+		assert.Equal(t, solutionKey(lcp.q), solutionKey(read.q))
+		assert.Equal(t, solutionKey(d), solutionKey(readD))
+	}
+}
+
+func TestReadBadFiles(t *testing.T) {
+
+	for _, input := range []string{
+		"",
+		"2 1 2 3",
+		"2 1 2 3 4 5 6 7",
+		"x",
+		"1 1 a",
+		`{"n": 2, "M": [["1"]], "q": ["1"]}`,
+		`{"n": 1, "M": [["1"]], "q": ["1"], "d": ["1", "2"]}`,
+		`{"n": 1, "M": [["x"]], "q": ["1"]}`,
+		`{"n": 1, "M": [[null]], "q": ["1"]}`,
+		"1.5 1 -1",
+	} {
+		_, _, err := ReadLCP(strings.NewReader(input))
+		assert.True(t, errors.Is(err, ErrFormat) || errors.Is(err, ErrDimension), input)
+	}
+}
+
+func TestJSONRat(t *testing.T) {
+
+	for _, input := range []string{`"3`, `3"`, `"1/0"`, `true`, `3x`} {
+		var r jsonRat
+		assert.True(t, errors.Is(r.UnmarshalJSON([]byte(input)), ErrFormat), input)
+	}
+
+	var r jsonRat
+	assert.Nil(t, r.UnmarshalJSON([]byte(` "-2/4" `)))
+	assert.Equal(t, "-1/2", r.RatString())
+	assert.Nil(t, r.UnmarshalJSON([]byte(`1e-2`)))
+	assert.Equal(t, "1/100", r.RatString())
+
+	_, err := jsonRat{}.MarshalJSON()
+	assert.True(t, errors.Is(err, ErrFormat))
+
+	lcp := newTestLCP(t, ints2rats([]int{1}), ints2rats([]int{-1}))
+	err = WriteJSON(&bytes.Buffer{}, lcp, []*big.Rat{nil})
+	assert.NotNil(t, err)
+}
+
+func TestWriteGAMS(t *testing.T) {
+
+	M := ints2rats([]int{2, 0, 1, 3})
+	M[2].SetFrac64(-1, 3)
+	q := ints2rats([]int{-1, 0})
+
+	var buf bytes.Buffer
+	assert.Nil(t, WriteGAMS(&buf, newTestLCP(t, M, q)))
+
+	gams := buf.String()
+	assert.Contains(t, gams, "Set i / 1*2 /;")
+	assert.Contains(t, gams, "M('1','1') = 2;")
+	assert.NotContains(t, gams, "M('1','2')")
+	assert.Contains(t, gams, "M('2','1') = (-1/3);")
+	assert.Contains(t, gams, "q('1') = -1;")
+	assert.Contains(t, gams, "Model lcp / f.z /;")
+}
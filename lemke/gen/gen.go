@@ -0,0 +1,140 @@
+// Package gen generates LCPs with covering vectors to benchmark and test
+// lemke: seeded random ones of several matrix classes and the classic
+// instances on which Lemke's algorithm takes exponentially many pivots.
+// The same arguments always give the same LCP.
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+
+	"github.com/megesdal/gametheory/lemke"
+)
+
+// ErrParameter means an argument other than the size is out of range.
+var ErrParameter = errors.New("gen: bad parameter")
+
+// maxEntry bounds the random integer entries, they are in
+// [-maxEntry, maxEntry].
+const maxEntry = 9
+
+// Dense is an n x n LCP whose M and q have random integer entries.  With
+// no structure to M Lemke's algorithm may well end on a ray.
+func Dense(n int, seed int64) (*lemke.LCP, []*big.Rat, error) {
+
+	r := rand.New(rand.NewSource(seed))
+	return newLCP(n, r, func(int, int) int64 {
+		return randomEntry(r)
+	})
+}
+
+// Sparse is Dense with each entry of M off the diagonal zero with
+// probability 1 - density.  The diagonal is positive.
+func Sparse(n int, density float64, seed int64) (*lemke.LCP, []*big.Rat, error) {
+
+	if density < 0 || density > 1 {
+		return nil, nil, fmt.Errorf("%w: density %g is not in [0, 1]", ErrParameter, density)
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	return newLCP(n, r, func(i int, j int) int64 {
+		if i == j {
+			return int64(1 + r.Intn(maxEntry))
+		}
+		if r.Float64() >= density {
+			return 0
+		}
+		return randomEntry(r)
+	})
+}
+
+// PSD is an n x n LCP with M = B'B + K - K' for random B and K, so M is
+// positive semidefinite and Lemke's algorithm solves it unless it has no
+// solution.
+func PSD(n int, seed int64) (*lemke.LCP, []*big.Rat, error) {
+
+	if n < 1 {
+		return nil, nil, dimensionError(n)
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	B := make([]int64, n*n)
+	K := make([]int64, n*n)
+	for k := range B {
+		B[k] = randomEntry(r)
+		K[k] = randomEntry(r)
+	}
+
+	return newLCP(n, r, func(i int, j int) int64 {
+		sum := K[i*n+j] - K[j*n+i]
+		for k := 0; k < n; k++ {
+			sum += B[k*n+i] * B[k*n+j]
+		}
+		return sum
+	})
+}
+
+// PMatrix is an n x n LCP whose M is strictly diagonally dominant with a
+// positive diagonal, hence a P-matrix, so the LCP has exactly one
+// solution whatever q.
+func PMatrix(n int, seed int64) (*lemke.LCP, []*big.Rat, error) {
+
+	r := rand.New(rand.NewSource(seed))
+	return newLCP(n, r, func(i int, j int) int64 {
+		if i == j {
+			return int64(maxEntry*(n-1) + 1 + r.Intn(maxEntry))
+		}
+		return randomEntry(r)
+	})
+}
+
+// newLCP fills M row by row from entry, then draws q with at least one
+// negative entry so that Lemke has something to do.  The covering vector
+// is all ones.
+func newLCP(n int, r *rand.Rand, entry func(i int, j int) int64) (*lemke.LCP, []*big.Rat, error) {
+
+	if n < 1 {
+		return nil, nil, dimensionError(n)
+	}
+
+	M := make([]*big.Rat, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			M[i*n+j] = big.NewRat(entry(i, j), 1)
+		}
+	}
+
+	q := make([]*big.Rat, n)
+	negative := false
+	for i := range q {
+		q[i] = big.NewRat(randomEntry(r), 1)
+		negative = negative || q[i].Sign() < 0
+	}
+	if !negative {
+		q[r.Intn(n)].SetInt64(-int64(1 + r.Intn(maxEntry)))
+	}
+
+	lcp, err := lemke.NewLCP(M, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lcp, ones(n), nil
+}
+
+func randomEntry(r *rand.Rand) int64 {
+	return int64(r.Intn(2*maxEntry+1) - maxEntry)
+}
+
+func ones(n int) []*big.Rat {
+	d := make([]*big.Rat, n)
+	for i := range d {
+		d[i] = big.NewRat(1, 1)
+	}
+	return d
+}
+
+func dimensionError(n int) error {
+	return fmt.Errorf("%w: cannot generate an LCP of size %d", lemke.ErrDimension, n)
+}
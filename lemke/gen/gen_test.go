@@ -0,0 +1,107 @@
+package gen
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/megesdal/gametheory/lemke"
+	"github.com/stretchr/testify/assert"
+)
+
+func entries(lcp *lemke.LCP) []string {
+	var strs []string
+	for i := 0; i < lcp.N(); i++ {
+		for j := 0; j < lcp.N(); j++ {
+			strs = append(strs, lcp.M(i, j).RatString())
+		}
+		strs = append(strs, lcp.Q(i).RatString())
+	}
+	return strs
+}
+
+func hasNegative(lcp *lemke.LCP) bool {
+	for i := 0; i < lcp.N(); i++ {
+		if lcp.Q(i).Sign() < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSeeded(t *testing.T) {
+
+	generators := map[string]func(seed int64) (*lemke.LCP, []*big.Rat, error){
+		"dense":  func(seed int64) (*lemke.LCP, []*big.Rat, error) { return Dense(6, seed) },
+		"sparse": func(seed int64) (*lemke.LCP, []*big.Rat, error) { return Sparse(6, 0.3, seed) },
+		"psd":    func(seed int64) (*lemke.LCP, []*big.Rat, error) { return PSD(6, seed) },
+		"p":      func(seed int64) (*lemke.LCP, []*big.Rat, error) { return PMatrix(6, seed) },
+	}
+
+	for name, generate := range generators {
+		first, d, err := generate(1)
+		assert.Nil(t, err, name)
+		assert.Equal(t, 6, first.N(), name)
+		assert.Equal(t, 6, len(d), name)
+		assert.True(t, hasNegative(first), name)
+
+		again, _, _ := generate(1)
+		assert.Equal(t, entries(first), entries(again), name)
+
+		other, _, _ := generate(2)
+		assert.NotEqual(t, entries(first), entries(other), name)
+	}
+}
+
+func TestSparseDensity(t *testing.T) {
+
+	lcp, _, err := Sparse(40, 0.1, 3)
+	assert.Nil(t, err)
+
+	nonzeros := 0
+	for i := 0; i < lcp.N(); i++ {
+		assert.True(t, lcp.M(i, i).Sign() > 0)
+		for j := 0; j < lcp.N(); j++ {
+			if i != j && lcp.M(i, j).Sign() != 0 {
+				nonzeros++
+			}
+		}
+	}
+	assert.True(t, nonzeros > 40 && nonzeros < 300)
+
+	_, _, err = Sparse(4, 1.5, 1)
+	assert.True(t, errors.Is(err, ErrParameter))
+}
+
+func TestMatrixClasses(t *testing.T) {
+
+	for seed := int64(1); seed <= 5; seed++ {
+		psd, d, err := PSD(5, seed)
+		assert.Nil(t, err)
+		assert.True(t, psd.IsPositiveSemidefinite().Holds)
+
+		_, err = lemke.SolveResult(psd, d, nil)
+		if err != nil {
+			assert.True(t, errors.Is(err, lemke.ErrRayTermination))
+		}
+
+		p, d, err := PMatrix(5, seed)
+		assert.Nil(t, err)
+		assert.True(t, p.IsPMatrix().Holds)
+
+		res, err := lemke.SolveResult(p, d, nil)
+		assert.Nil(t, err)
+		report, _ := lemke.Verify(p, res.Z)
+		assert.True(t, report.OK())
+	}
+}
+
+func TestBadSize(t *testing.T) {
+
+	_, _, err := Dense(0, 1)
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+	_, _, err = PSD(-1, 1)
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+	_, _, err = Murty(0)
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+}
@@ -0,0 +1,246 @@
+package gen
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/megesdal/gametheory/lemke"
+	"github.com/megesdal/gametheory/nash"
+)
+
+// Murty is Murty's n x n LCP on which Lemke's algorithm with covering
+// vector d = (1, ..., 1) makes 2^n pivots: M is lower triangular with
+// ones on the diagonal and twos below it, and q = (-1, ..., -1).
+func Murty(n int) (*lemke.LCP, []*big.Rat, error) {
+	return hardLCP(n, murty)
+}
+
+// Fathi is Fathi's n x n LCP on which Lemke's algorithm with covering
+// vector d = (1, ..., 1) makes 2^n pivots although M is symmetric and
+// positive definite: M = LL' for the matrix L of Murty, and
+// q = (-1, ..., -1).
+func Fathi(n int) (*lemke.LCP, []*big.Rat, error) {
+	return hardLCP(n, func(i int, j int) int64 {
+		var sum int64
+		for k := 0; k <= i && k <= j; k++ {
+			sum += murty(i, k) * murty(j, k)
+		}
+		return sum
+	})
+}
+
+func murty(i int, j int) int64 {
+	switch {
+	case i == j:
+		return 1
+	case i > j:
+		return 2
+	}
+	return 0
+}
+
+func hardLCP(n int, entry func(i int, j int) int64) (*lemke.LCP, []*big.Rat, error) {
+
+	if n < 1 {
+		return nil, nil, dimensionError(n)
+	}
+
+	M := make([]*big.Rat, n*n)
+	q := make([]*big.Rat, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			M[i*n+j] = big.NewRat(entry(i, j), 1)
+		}
+		q[i] = big.NewRat(-1, 1)
+	}
+
+	lcp, err := lemke.NewLCP(M, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lcp, ones(n), nil
+}
+
+// MorrisImitation is the LCP that nash solves for the d x d game of
+// MorrisImitationPayoffs when both players' prior is their pure
+// strategy k, from 0, with the covering vector of that prior.  The
+// number of pivots grows exponentially in d for every k.
+func MorrisImitation(d int, k int) (*lemke.LCP, []*big.Rat, error) {
+
+	payoffs, err := MorrisImitationPayoffs(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	if k < 0 || k >= d {
+		return nil, nil, fmt.Errorf("%w: no strategy %d in a %d x %d game", ErrParameter, k, d, d)
+	}
+
+	prior := make([]*big.Rat, d)
+	for i := range prior {
+		prior[i] = new(big.Rat)
+	}
+	prior[k].SetInt64(1)
+
+	return nash.LemkeLCP(payoffs, prior, prior)
+}
+
+// MorrisImitationPayoffs is a d x d imitation game, d even, with
+// exponentially long Lemke paths.  The row player's best response
+// polytope is the dual cyclic polytope in dimension d with 2d facets,
+// labelled after Morris so that complementary paths are long whichever
+// label is missing, and the column player imitates the row player, i.e.
+// its payoffs are the identity matrix.  The payoffs are in the layout of
+// nash.
+func MorrisImitationPayoffs(d int) ([]*big.Rat, error) {
+
+	if d < 2 || d%2 != 0 {
+		return nil, fmt.Errorf("%w: the dimension %d must be even and positive", ErrParameter, d)
+	}
+
+	A := dualCyclic(d)
+
+	payoffs := make([]*big.Rat, 2*d*d)
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+			payoffs[(i*d+j)*2] = A[i*d+j]
+			payoffs[(i*d+j)*2+1] = new(big.Rat)
+			if i == j {
+				payoffs[(i*d+j)*2+1].SetInt64(1)
+			}
+		}
+	}
+	return payoffs, nil
+}
+
+/*
+ * cyclicLabels
+ * ================================================================
+ * labels of the 2d facets of the dual cyclic polytope, in the order
+ * of the moment curve, chosen so that complementary paths are long:
+ * 1 .. d,  then  d,  the pairs  (d-2, d-1), (d-4, d-3), ..., (2, 3),
+ * and last  1.  This is Morris's labelling: facet  k  gets  l(k) = k
+ * for  k <= d,  and facet  d + i  gets  l(d+1) = d,  l(d+i) = d - i
+ * for even  i < d,  l(d+i) = d - i + 2  for odd  i > 1,  and
+ * l(2d) = 1.  By Gale's evenness condition the first d facets meet in
+ * a vertex, which gets every label once and so is the artificial
+ * start of a complementary path.
+ */
+func cyclicLabels(d int) []int {
+
+	labels := make([]int, 0, 2*d)
+	for i := 1; i <= d; i++ {
+		labels = append(labels, i)
+	}
+	labels = append(labels, d)
+	for k := d - 2; k > 1; k -= 2 {
+		labels = append(labels, k, k+1)
+	}
+	return append(labels, 1)
+}
+
+/*
+ * dualCyclic
+ * ================================================================
+ * the d x d matrix A, row by row, of the polytope  {y >= 0 | Ay <= 1}
+ * that is the dual cyclic polytope
+ *     {x | p(t)'x <= 1,  t = 1 .. 2d}
+ * with  p(t) = (t, t^2, ..., t^d) - c  for the centroid c of these
+ * points, in coordinates where its first d facets are  y >= 0:
+ *     y = 1 - Gx  for the rows  p(1) .. p(d)  of G.
+ * Facet t > d of the polytope is then
+ *     -p(t)'G^-1 y <= 1 - p(t)'G^-1 1
+ * and becomes row  cyclicLabels[t]  of A once divided by the right side,
+ * which is positive as the vertex  y = 0  is not on the facet.
+ */
+func dualCyclic(d int) []*big.Rat {
+
+	n := 2 * d
+	points := make([][]*big.Rat, n)
+	centroid := make([]*big.Rat, d)
+	for j := range centroid {
+		centroid[j] = new(big.Rat)
+	}
+	for t := 0; t < n; t++ {
+		points[t] = make([]*big.Rat, d)
+		power := big.NewRat(1, 1)
+		for j := 0; j < d; j++ {
+			power = new(big.Rat).Mul(power, big.NewRat(int64(t+1), 1))
+			points[t][j] = power
+			centroid[j].Add(centroid[j], power)
+		}
+	}
+	for j := range centroid {
+		centroid[j].Quo(centroid[j], big.NewRat(int64(n), 1))
+	}
+	for t := range points {
+		for j := range points[t] {
+			points[t][j] = new(big.Rat).Sub(points[t][j], centroid[j])
+		}
+	}
+
+	Ginv := inverse(points[:d])
+	labels := cyclicLabels(d)
+
+	A := make([]*big.Rat, d*d)
+	tmp := new(big.Rat)
+	for t := d; t < n; t++ {
+		row := (labels[t] - 1) * d
+		rhs := big.NewRat(1, 1)
+		for j := 0; j < d; j++ {
+			coef := new(big.Rat)
+			for i := 0; i < d; i++ {
+				coef.Add(coef, tmp.Mul(points[t][i], Ginv[i][j]))
+			}
+			rhs.Sub(rhs, coef)
+			A[row+j] = coef.Neg(coef)
+		}
+		for j := 0; j < d; j++ {
+			A[row+j].Quo(A[row+j], rhs)
+		}
+	}
+	return A
+}
+
+// inverse of the nonsingular square matrix G by Gauss-Jordan elimination
+func inverse(G [][]*big.Rat) [][]*big.Rat {
+
+	n := len(G)
+	aug := make([][]*big.Rat, n)
+	for i := range aug {
+		aug[i] = make([]*big.Rat, 2*n)
+		for j := 0; j < n; j++ {
+			aug[i][j] = new(big.Rat).Set(G[i][j])
+			aug[i][n+j] = new(big.Rat)
+		}
+		aug[i][n+i].SetInt64(1)
+	}
+
+	tmp := new(big.Rat)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for aug[pivot][col].Sign() == 0 {
+			pivot++
+		}
+		aug[col], aug[pivot] = aug[pivot], aug[col]
+
+		pivelt := new(big.Rat).Set(aug[col][col])
+		for j := range aug[col] {
+			aug[col][j].Quo(aug[col][j], pivelt)
+		}
+		for i := 0; i < n; i++ {
+			if i == col || aug[i][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(aug[i][col])
+			for j := range aug[i] {
+				aug[i][j].Sub(aug[i][j], tmp.Mul(factor, aug[col][j]))
+			}
+		}
+	}
+
+	inv := make([][]*big.Rat, n)
+	for i := range inv {
+		inv[i] = aug[i][n:]
+	}
+	return inv
+}
@@ -0,0 +1,88 @@
+package gen
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/megesdal/gametheory/lemke"
+	"github.com/megesdal/gametheory/nash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMurtyExponential(t *testing.T) {
+
+	for n := 1; n <= 8; n++ {
+		lcp, d, err := Murty(n)
+		assert.Nil(t, err)
+
+		res, err := lemke.SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 1<<n, res.Pivots)
+	}
+}
+
+func TestFathiExponential(t *testing.T) {
+
+	for n := 1; n <= 8; n++ {
+		lcp, d, err := Fathi(n)
+		assert.Nil(t, err)
+		assert.True(t, lcp.IsPMatrix().Holds)
+
+		res, err := lemke.SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 1<<n, res.Pivots)
+	}
+}
+
+func TestCyclicLabels(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3, 4, 4, 2, 3, 1}, cyclicLabels(4))
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 6, 4, 5, 2, 3, 1}, cyclicLabels(6))
+}
+
+func TestMorrisImitationExponential(t *testing.T) {
+
+	// the longest and the shortest path over all priors
+	longest := map[int]int{4: 19, 6: 41, 8: 97, 10: 229, 12: 537}
+	shortest := map[int]int{4: 13, 6: 23, 8: 31, 10: 49, 12: 73}
+	maxPivots, minPivots := map[int]int{}, map[int]int{}
+	for d := 4; d <= 12; d += 2 {
+		for k := 0; k < d; k++ {
+			lcp, cov, err := MorrisImitation(d, k)
+			assert.Nil(t, err)
+
+			res, err := lemke.SolveResult(lcp, cov, nil)
+			assert.Nil(t, err)
+			if res.Pivots > maxPivots[d] {
+				maxPivots[d] = res.Pivots
+			}
+			if k == 0 || res.Pivots < minPivots[d] {
+				minPivots[d] = res.Pivots
+			}
+		}
+		assert.Equal(t, longest[d], maxPivots[d], "d = %d", d)
+		assert.Equal(t, shortest[d], minPivots[d], "d = %d", d)
+
+		// both grow by a constant factor from one d to the next
+		if d > 4 {
+			assert.Greater(t, maxPivots[d], 2*maxPivots[d-2], "d = %d", d)
+			assert.Greater(t, 4*minPivots[d], 5*minPivots[d-2], "d = %d", d)
+		}
+	}
+}
+
+func TestMorrisImitationGame(t *testing.T) {
+
+	payoffs, err := MorrisImitationPayoffs(4)
+	assert.Nil(t, err)
+
+	prior := []*big.Rat{big.NewRat(1, 1), new(big.Rat), new(big.Rat), new(big.Rat)}
+	eq, err := nash.LemkeEquilibriumWithPriors(payoffs, prior, prior)
+	assert.Nil(t, err)
+	assert.NotNil(t, eq)
+
+	_, err = MorrisImitationPayoffs(5)
+	assert.True(t, errors.Is(err, ErrParameter))
+	_, _, err = MorrisImitation(4, 4)
+	assert.True(t, errors.Is(err, ErrParameter))
+}
@@ -0,0 +1,265 @@
+package lemke
+
+import (
+	"math"
+	"math/big"
+)
+
+/*
+ * int64Storage
+ * ================================================================
+ * dense storage in machine words for the common case of small integer
+ * payoffs.  Pivoting is the same integer step as for denseStorage, but
+ * every product and difference is checked; the first time an entry does
+ * not fit, the whole tableau is promoted to big.Int and stays there.
+ * A pivot is computed into a second buffer so that an overflow halfway
+ * leaves the tableau unchanged and the pivot is simply redone in
+ * big.Int arithmetic.
+ */
+type int64Storage struct {
+	matrix  []int64
+	next    []int64 // buffer for the pivot
+	ncols   int
+	nrows   int
+	promote *denseStorage // all entries, once promoted
+}
+
+func newInt64Storage(nrows int, ncols int) *int64Storage {
+	return &int64Storage{
+		matrix: make([]int64, nrows*ncols),
+		next:   make([]int64, nrows*ncols),
+		ncols:  ncols,
+		nrows:  nrows,
+	}
+}
+
+// promoted is whether the entries are now kept as big.Int.
+func (S *int64Storage) promoted() bool {
+	return S.promote != nil
+}
+
+func (S *int64Storage) toBig() {
+	S.promote = newDenseStorage(S.nrows, S.ncols)
+	for k, value := range S.matrix {
+		S.promote.matrix[k] = big.NewInt(value)
+	}
+	S.matrix, S.next = nil, nil
+}
+
+func (S *int64Storage) set(row int, col int, value *big.Int) {
+	if S.promote == nil && !value.IsInt64() {
+		S.toBig()
+	}
+	if S.promote != nil {
+		S.promote.set(row, col, value)
+		return
+	}
+	S.matrix[row*S.ncols+col] = value.Int64()
+}
+
+func (S *int64Storage) entry(row int, col int) *big.Int {
+	if S.promote != nil {
+		return S.promote.entry(row, col)
+	}
+	return big.NewInt(S.matrix[row*S.ncols+col])
+}
+
+func (S *int64Storage) sign(row int, col int) (int, bool) {
+	if S.promote != nil {
+		return 0, false
+	}
+	value := S.matrix[row*S.ncols+col]
+	switch {
+	case value > 0:
+		return 1, true
+	case value < 0:
+		return -1, true
+	}
+	return 0, true
+}
+
+// ratioTest is tableau.ratioTest, false if a product overflows
+func (S *int64Storage) ratioTest(rowA int, rowB int, colA int, colB int) (int, bool) {
+	if S.promote != nil {
+		return 0, false
+	}
+	a, okA := mul64(S.matrix[rowA*S.ncols+colB], S.matrix[rowB*S.ncols+colA])
+	b, okB := mul64(S.matrix[rowB*S.ncols+colB], S.matrix[rowA*S.ncols+colA])
+	switch {
+	case !okA || !okB:
+		return 0, false
+	case a > b:
+		return 1, true
+	case a < b:
+		return -1, true
+	}
+	return 0, true
+}
+
+func (S *int64Storage) fillIn() int {
+	return 0
+}
+
+func (S *int64Storage) pivot(row int, col int, det *big.Int, workers int) *big.Int {
+
+	if S.promote == nil {
+		if newdet, ok := S.pivot64(row, col, det, workers); ok {
+			return newdet
+		}
+		S.toBig()
+	}
+	return S.promote.pivot(row, col, det, workers)
+}
+
+// pivot64 is denseStorage.pivot in int64, false if anything overflows
+func (S *int64Storage) pivot64(row int, col int, det *big.Int, workers int) (*big.Int, bool) {
+
+	if !det.IsInt64() {
+		return nil, false
+	}
+	d := det.Int64()
+
+	pivelt := S.matrix[row*S.ncols+col]
+	negpiv := pivelt < 0
+	if negpiv {
+		if pivelt == math.MinInt64 {
+			return nil, false
+		}
+		pivelt = -pivelt
+	}
+
+	overflow := make([]bool, rowWorkers(S.nrows, workers))
+	forRows(S.nrows, workers, func(w int, lo int, hi int) {
+		for i := lo; i < hi && !overflow[w]; i++ {
+			overflow[w] = !S.pivotRow64(i, row, col, pivelt, d, negpiv)
+		}
+	})
+	for _, o := range overflow {
+		if o {
+			return nil, false
+		}
+	}
+
+	nextRow := S.next[row*S.ncols : (row+1)*S.ncols]
+	nextRow[col] = d
+	if negpiv {
+		for j := range nextRow {
+			if nextRow[j] == math.MinInt64 {
+				return nil, false
+			}
+			nextRow[j] = -nextRow[j]
+		}
+	}
+
+	S.matrix, S.next = S.next, S.matrix
+	return big.NewInt(pivelt), true
+}
+
+// pivotRow64 writes row  i  after the pivot into the buffer, false if
+// anything overflows
+func (S *int64Storage) pivotRow64(i int, row int, col int, pivelt int64, d int64, negpiv bool) bool {
+
+	rowI := S.matrix[i*S.ncols : (i+1)*S.ncols]
+	nextI := S.next[i*S.ncols : (i+1)*S.ncols]
+	if i == row { // A[row][..] remains unchanged, up to its sign
+		copy(nextI, rowI)
+		return true
+	}
+
+	entry := rowI[col]
+	for j := 0; j < S.ncols; j++ {
+		if j == col {
+			continue
+		}
+
+		//A[i,j] = (A[i,j] A[row,col] - A[i,col] A[row,j]) / det
+		value, ok := mul64(rowI[j], pivelt)
+		if !ok {
+			return false
+		}
+		if entry != 0 {
+			tmp, ok := mul64(entry, S.matrix[row*S.ncols+j])
+			if !ok {
+				return false
+			}
+			if negpiv {
+				value, ok = add64(value, tmp)
+			} else {
+				value, ok = sub64(value, tmp)
+			}
+			if !ok {
+				return false
+			}
+		}
+		if d == -1 && value == math.MinInt64 {
+			return false
+		}
+		nextI[j] = value / d // exact
+	}
+	if entry != 0 && !negpiv {
+		entry = -entry
+	}
+	nextI[col] = entry
+	return true
+}
+
+func (S *int64Storage) negateRow(row int) {
+	if S.promote == nil && !S.negatable(row*S.ncols, 1, S.ncols) {
+		S.toBig()
+	}
+	if S.promote != nil {
+		S.promote.negateRow(row)
+		return
+	}
+	for j := 0; j < S.ncols; j++ {
+		S.matrix[row*S.ncols+j] = -S.matrix[row*S.ncols+j]
+	}
+}
+
+func (S *int64Storage) negateCol(col int) {
+	if S.promote == nil && !S.negatable(col, S.ncols, S.nrows) {
+		S.toBig()
+	}
+	if S.promote != nil {
+		S.promote.negateCol(col)
+		return
+	}
+	for i := 0; i < S.nrows; i++ {
+		S.matrix[i*S.ncols+col] = -S.matrix[i*S.ncols+col]
+	}
+}
+
+// negatable is false if one of the count entries from start on with the
+// given stride is MinInt64, which has no negative in int64
+func (S *int64Storage) negatable(start int, stride int, count int) bool {
+	for k := 0; k < count; k++ {
+		if S.matrix[start+k*stride] == math.MinInt64 {
+			return false
+		}
+	}
+	return true
+}
+
+// mul64 is  a b  and whether it fits into an int64
+func mul64(a int64, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return c, true
+}
+
+// add64 is  a + b  and whether it fits into an int64
+func add64(a int64, b int64) (int64, bool) {
+	c := a + b
+	return c, (c > a) == (b > 0)
+}
+
+// sub64 is  a - b  and whether it fits into an int64
+func sub64(a int64, b int64) (int64, bool) {
+	c := a - b
+	return c, (c < a) == (b > 0)
+}
@@ -0,0 +1,123 @@
+package lemke
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckedArithmetic(t *testing.T) {
+
+	_, ok := mul64(math.MaxInt64, 2)
+	assert.False(t, ok)
+	_, ok = mul64(-1, math.MinInt64)
+	assert.False(t, ok)
+	c, ok := mul64(-3, 7)
+	assert.True(t, ok)
+	assert.Equal(t, int64(-21), c)
+
+	_, ok = add64(math.MaxInt64, 1)
+	assert.False(t, ok)
+	_, ok = sub64(math.MinInt64, 1)
+	assert.False(t, ok)
+	c, ok = sub64(-5, -7)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), c)
+}
+
+func solveWithStorage(t *testing.T, lcp *LCP, d []*big.Rat, kind StorageKind) (*Result, *tableau) {
+	tableau, scaleFactors, err := createTableau(lcp, d, kind)
+	assert.Nil(t, err)
+	res, err := run(context.Background(), tableau, scaleFactors, &Options{})
+	assert.Nil(t, err)
+	return res, tableau
+}
+
+func TestInt64MatchesDense(t *testing.T) {
+
+	for seed := int64(1); seed <= 20; seed++ {
+		lcp, d := bimatrixTestLCP(t, 4, 10, seed)
+
+		expected, _ := solveWithStorage(t, lcp, d, DenseStorage)
+		res, tableau := solveWithStorage(t, lcp, d, Int64Storage)
+		assert.False(t, tableau.store.(*int64Storage).promoted())
+
+		assert.Equal(t, solutionKey(expected.Z), solutionKey(res.Z))
+		assert.Equal(t, expected.Basis, res.Basis)
+		assert.Equal(t, expected.Pivots, res.Pivots)
+	}
+}
+
+func TestInt64PromotesOnOverflow(t *testing.T) {
+
+	// entries near 2^40 overflow after the second pivot
+	big40 := int(1) << 40
+	lcp, d := randomTestLCP(t, 6, 3)
+	for i := 0; i < 6; i++ {
+		lcp.m[i*6+i].SetInt64(int64(big40 + i))
+	}
+
+	expected, _ := solveWithStorage(t, lcp, d, DenseStorage)
+	res, tableau := solveWithStorage(t, lcp, d, Int64Storage)
+	assert.True(t, tableau.store.(*int64Storage).promoted())
+
+	assert.Equal(t, solutionKey(expected.Z), solutionKey(res.Z))
+	assert.Equal(t, expected.Basis, res.Basis)
+	assert.Equal(t, expected.Pivots, res.Pivots)
+}
+
+func TestInt64PromotesOnSet(t *testing.T) {
+
+	S := newInt64Storage(1, 2)
+	S.set(0, 0, big.NewInt(5))
+	assert.False(t, S.promoted())
+
+	huge := new(big.Int).Lsh(big.NewInt(1), 70)
+	S.set(0, 1, huge)
+	assert.True(t, S.promoted())
+	assert.Equal(t, "5", S.entry(0, 0).String())
+	assert.Equal(t, huge.String(), S.entry(0, 1).String())
+}
+
+func TestInt64PromotesOnNegatedDivision(t *testing.T) {
+
+	// the first pivot divides by det = -1, so an entry of -2^63 turns into 2^63
+	S := newInt64Storage(2, 2)
+	dense := newDenseStorage(2, 2)
+	for _, store := range []storage{S, dense} {
+		store.set(0, 0, big.NewInt(1))
+		store.set(0, 1, big.NewInt(0))
+		store.set(1, 0, big.NewInt(0))
+		store.set(1, 1, big.NewInt(math.MinInt64))
+	}
+	det := S.pivot(0, 0, big.NewInt(-1), 1)
+	expected := dense.pivot(0, 0, big.NewInt(-1), 1)
+	assert.True(t, S.promoted())
+	assert.Equal(t, expected.String(), det.String())
+	for row := 0; row < 2; row++ {
+		for col := 0; col < 2; col++ {
+			assert.Equal(t, dense.entry(row, col).String(), S.entry(row, col).String())
+		}
+	}
+	assert.Equal(t, "9223372036854775808", S.entry(1, 1).String())
+}
+
+func BenchmarkPivotDense(b *testing.B) {
+	benchmarkPivot(b, DenseStorage)
+}
+
+func BenchmarkPivotInt64(b *testing.B) {
+	benchmarkPivot(b, Int64Storage)
+}
+
+func benchmarkPivot(b *testing.B, kind StorageKind) {
+	lcp, d := bimatrixTestLCP(&testing.T{}, 6, 5, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tableau, scaleFactors, _ := createTableau(lcp, d, kind)
+		run(context.Background(), tableau, scaleFactors, &Options{})
+	}
+}
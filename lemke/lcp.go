@@ -21,20 +21,29 @@ type LCP struct {
 	n int
 }
 
-func NewLCP(M []*big.Rat, q []*big.Rat) *LCP {
+// NewLCP creates the LCP for the n x n matrix M, given row by row, and the
+// n vector q.  Returns an error wrapping ErrDimension if they do not fit.
+func NewLCP(M []*big.Rat, q []*big.Rat) (*LCP, error) {
 
 	n := len(q)
+	if n == 0 {
+		return nil, fmt.Errorf("%w: q must not be empty", ErrDimension)
+	}
+
 	if len(M)%n != 0 {
-		panic("M.rows and q are not same dimensions")
+		return nil, fmt.Errorf("%w: M.rows and q are not same dimensions", ErrDimension)
 	}
 
 	if len(M)/n != n {
-		panic(fmt.Sprintf("M must be a square matrix but was %dx%d", len(M)/n, n))
+		return nil, fmt.Errorf("%w: M must be a square matrix but was %dx%d", ErrDimension, len(M)/n, n)
 	}
 
-	fmt.Printf("Creating LCP of dimenstion n=%d\n", n)
+	return &LCP{m: M, q: q, n: n}, nil
+}
 
-	return &LCP{m: M, q: q, n: n}
+// N is the size of the LCP, M is N x N.
+func (lcp *LCP) N() int {
+	return lcp.n
 }
 
 func (lcp *LCP) M(i int, j int) *big.Rat {
@@ -1,67 +1,247 @@
 package lemke
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"math/big"
+	"math/rand"
 )
 
 // Solve the linear complementarity probelm via Lemke's algorithm.
-// Returns nil with an error if ray termination
+// Returns an error wrapping ErrRayTermination if ray termination
 func Solve(lcp *LCP, d []*big.Rat) ([]*big.Rat, error) {
 	return SolveWithPivotMax(lcp, d, 0)
 }
 
+// SolveContext is Solve but gives up with a *CanceledError as soon as ctx
+// is done.
+func SolveContext(ctx context.Context, lcp *LCP, d []*big.Rat) ([]*big.Rat, error) {
+
+	res, err := SolveResultContext(ctx, lcp, d, nil)
+	if res == nil {
+		return nil, err
+	}
+	return res.Z, err
+}
+
 // SolveWithPivotMax will only perform up to maxCount pivots before exiting.
 func SolveWithPivotMax(lcp *LCP, d []*big.Rat, maxCount int) ([]*big.Rat, error) {
+	return SolveWithOptions(lcp, d, &Options{MaxPivots: maxCount})
+}
 
-	tableau, scaleFactors, err := createTableau(lcp, d)
-	if err != nil {
+// Options tunes a Lemke run.  The zero value (or nil) runs to completion
+// without reporting anything.
+type Options struct {
+	// MaxPivots stops the run after this many pivots, 0 means no limit.
+	MaxPivots int
+
+	// Observer, if set, is told about every pivot.
+	Observer Observer
+
+	// Snapshots adds a copy of the tableau to every PivotStep given to
+	// the Observer.
+	Snapshots bool
+
+	// FloatPivoting first runs Lemke in float64 arithmetic and then only
+	// solves the basic system of the final basis exactly, also when it
+	// stops at MaxPivots.  If that basis is not an exact solution Lemke is
+	// run again in exact arithmetic.  The Observer only sees exact pivots.
+	FloatPivoting bool
+
+	// Tolerance below which float entries count as zero, DefaultTolerance
+	// if 0.  Only used with FloatPivoting.
+	Tolerance float64
+
+	// WarmStart is a complementary basis, e.g. Result.Basis of an earlier
+	// run, to start from instead of the all w basis.  The tableau is
+	// pivoted to it first, these pivots count in Result.Pivots and
+	// Result.FactorPivots and the Observer sees them.  Lemke continues
+	// from there with d read in that basis: z0 raises the basic variable
+	// of pair i, z(i) or w(i), by d(i), so d(i) must be positive where
+	// that variable is negative.  Takes precedence over FloatPivoting.
+	WarmStart []Variable
+
+	// Method is the algorithm to run, Lemke if not set.  With
+	// PrincipalPivoting d is not used and may be nil, FloatPivoting and
+	// WarmStart are ignored and q >= 0 is simply solved by z = 0.
+	Method Method
+
+	// Storage is how the tableau is kept, AutoStorage picks sparse storage
+	// for large LCPs that are mostly zero, as long as they stay so, and
+	// machine words otherwise.
+	// Float pivoting is always dense.
+	Storage StorageKind
+
+	// PivotRule breaks ties in the minimum ratio test, Lexicographic if
+	// nil.  Result.Ties records how often it was needed.
+	PivotRule PivotRule
+
+	// Workers is how many goroutines share the row updates of a pivot,
+	// which helps for large tableaus such as those of the sequence form.
+	// Results do not depend on it.  Fewer than two pivot serially.
+	Workers int
+
+	// Concurrent is how many covering vectors SolveBatch solves at once,
+	// zero or one solves them in turn.  The Observer, if any, must then be
+	// safe for concurrent use.
+	Concurrent int
+
+	// Restarts is how many more covering vectors to try if Lemke ends on
+	// a ray or at MaxPivots.  Each comes from CoveringVector, or if that
+	// is nil has random positive entries, drawn from a source seeded with
+	// Seed.  WarmStart only applies to the first attempt and
+	// Result.Attempts tells how many were made.
+	Restarts       int
+	Seed           int64
+	CoveringVector func(r *rand.Rand) []*big.Rat
+
+	// BlockSize, if positive, says that M = [ 0 P ; Q 0 ] with a zero
+	// diagonal block of that size first, as in the LCP of a bimatrix game.
+	// Lemke then keeps one integer tableau per block, in Storage, and
+	// takes exactly the same pivots as on the full tableau with about
+	// half the entries to update.  M must have that form, FloatPivoting,
+	// WarmStart and Snapshots cannot be combined with it and PivotStep
+	// carries no Det.
+	BlockSize int
+}
+
+// SolveWithOptions runs Lemke's algorithm as configured by opts.
+func SolveWithOptions(lcp *LCP, d []*big.Rat, opts *Options) ([]*big.Rat, error) {
+
+	res, err := SolveResult(lcp, d, opts)
+	if res == nil {
 		return nil, err
 	}
+	return res.Z, err // LCP solution = z  vector
+}
 
-	nextLeavingVar := func(enter *tableauVariable) (*tableauVariable, bool, error) {
-		return lexminratio(tableau, enter)
+// SolveResult runs Lemke's algorithm as configured by opts and reports
+// the final basis along with the solution.  On ray termination both the
+// Result and the error are returned.
+func SolveResult(lcp *LCP, d []*big.Rat, opts *Options) (*Result, error) {
+	return SolveResultContext(context.Background(), lcp, d, opts)
+}
+
+// SolveResultContext is SolveResult but checks ctx before every pivot.
+// Once ctx is done the Result reached so far is returned together with a
+// *CanceledError holding the same Result.
+func SolveResultContext(ctx context.Context, lcp *LCP, d []*big.Rat, opts *Options) (*Result, error) {
+
+	if opts == nil {
+		opts = &Options{}
 	}
 
-	// z0 enters the basis to obtain lex-feasible solution
-	enter := tableau.vars.z(0)
-	leave, z0leave, err := nextLeavingVar(enter)
+	if _, err := opts.pivotRule(lcp.n); err != nil {
+		return nil, err
+	}
 
-	// now give the entering q-col its correct sign
-	tableau.negateCol(tableau.rhsCol())
+	if err := opts.checkBlock(); err != nil {
+		return nil, err
+	}
 
-	pivotCount := 1
-	for {
+	if opts.Method == PrincipalPivoting {
+		return principalPivoting(ctx, lcp, opts)
+	}
 
-		fmt.Printf("%d LCP:\n%v", pivotCount, tableau)
-		fmt.Println(pivotCount, "entering", enter, "leaving", leave)
+	return withRestarts(lcp.n, d, opts, func(d []*big.Rat, opts *Options) (*Result, error) {
+		return solveLemke(ctx, lcp, d, opts)
+	})
+}
 
-		tableau.pivot(leave, enter)
+// solveLemke is one attempt of SolveResultContext.
+func solveLemke(ctx context.Context, lcp *LCP, d []*big.Rat, opts *Options) (*Result, error) {
 
-		if z0leave {
-			break // z0 will have a value of zero but may still be basic... amend?
-		}
+	if opts.FloatPivoting && opts.WarmStart == nil {
+		return solveFloatOrExact(ctx, lcp, d, opts)
+	}
 
-		// selectpivot
-		enter = leave.complement()
-		fmt.Printf("Complement of %s is %s\n", leave, enter)
+	s, err := NewSolver(lcp, d, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.solve(ctx)
+}
 
-		leave, z0leave, err = nextLeavingVar(enter)
-		if err != nil {
-			break // ray termination...
-		}
+// solveFloatOrExact pivots in float64 and verifies the basis exactly,
+// starting over exactly if it does not hold up.
+func solveFloatOrExact(ctx context.Context, lcp *LCP, d []*big.Rat, opts *Options) (*Result, error) {
 
-		if pivotCount == maxCount { /* maxcount == 0 is equivalent to infinity since pivotcount starts at 1 */
-			//log.warning(String.format("------- stop after %d pivoting steps --------", maxcount));
-			break
-		}
+	if err := checkInputs(lcp.q, d); err != nil {
+		return nil, err
+	}
 
-		pivotCount++
+	res, err := solveFloat(ctx, lcp, d, opts)
+	if res != nil || err != nil {
+		return res, err
 	}
 
-	fmt.Printf("LCP (final):\n%v", tableau)
-	return solution(tableau, scaleFactors), err // LCP solution = z  vector
+	// float basis rejected, start over exactly
+	tableau, scaleFactors, _ := createTableau(lcp, d, opts.Storage)
+	tableau.workers = opts.Workers
+	res, err = run(ctx, tableau, scaleFactors, opts)
+	if res != nil {
+		res.FloatFallback = true
+	}
+	return res, err
+}
+
+/*
+ * run
+ * ================================================================
+ * Lemke's algorithm proper on a freshly created tableau
+ */
+func run(ctx context.Context, tableau *tableau, scaleFactors []*big.Int, opts *Options) (*Result, error) {
+
+	s, err := startLemke(tableau, scaleFactors, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.solve(ctx)
+}
+
+// startLemke makes the first ratio test of a cold start, the Solver does
+// the pivots.
+func startLemke(tableau *tableau, scaleFactors []*big.Int, opts *Options) (*Solver, error) {
+
+	// z0 enters the basis to obtain lex-feasible solution
+	enter := tableau.vars.z(0)
+	rule, _ := opts.pivotRule(tableau.vars.n)
+	leave, z0leave, ties, err := minratio(tableau, enter, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	// now give the entering q-col its correct sign
+	tableau.negateCol(tableau.rhsCol())
+
+	return newSolver(scaledTableau{tableau, scaleFactors}, opts, enter, leave, z0leave, ties), nil
+}
+
+// lemkeTableau is what a Solver pivots: the integer tableau
+// with its scale factors, the block tableau of a bimatrix LCP, or a
+// tableau in an OrderedField.
+type lemkeTableau interface {
+	ratioTableau
+	pivot(leave *tableauVariable, enter *tableauVariable) (int, int, error)
+	result(status Status, pivots int) *Result
+	ray(enter *tableauVariable) []*big.Rat
+	pivotStep(count int, enter *tableauVariable, leave *tableauVariable, row int, col int, snapshot bool) *PivotStep
+	snapshot() *Snapshot
+}
+
+// scaledTableau is the integer tableau and the column scale factors that
+// turn its entries back into rationals.
+type scaledTableau struct {
+	*tableau
+	scaleFactors []*big.Int
+}
+
+func (T scaledTableau) result(status Status, pivots int) *Result {
+	return newResult(T.tableau, T.scaleFactors, status, pivots)
+}
+
+func (T scaledTableau) ray(enter *tableauVariable) []*big.Rat {
+	return ray(T.tableau, T.scaleFactors, enter)
 }
 
 /*
@@ -72,7 +252,7 @@ func SolveWithPivotMax(lcp *LCP, d []*big.Rat, maxCount int) ([]*big.Rat, error)
 func solution(tableau *tableau, scaleFactors []*big.Int) []*big.Rat {
 
 	z := make([]*big.Rat, tableau.vars.n)
-	den := new(big.Int).Mul(tableau.det, scaleFactors[tableau.rhsCol()]) // TODO: okay? or do I need a fresh copy each time?
+	den := new(big.Int).Mul(tableau.det, scaleFactors[tableau.rhsCol()])
 	for i := 0; i < len(z); i++ {
 		// skip z0... just z(1)..z(n)
 		z[i] = result(tableau.vars.z(i+1), den, tableau, scaleFactors)
@@ -91,7 +271,7 @@ func result(tvar *tableauVariable, den *big.Int, tableau *tableau, scaleFactors
 
 		var num *big.Int
 		if tvar.isZ() {
-			num = scaleFactors[tvar.idx]
+			num = new(big.Int).Set(scaleFactors[tvar.idx])
 		} else {
 			num = big.NewInt(1)
 		}
@@ -110,22 +290,48 @@ func result(tvar *tableauVariable, den *big.Int, tableau *tableau, scaleFactors
 * scfa[Z(1..n)] for cols of  M
 * result variables to be multiplied with these
  */
-func createTableau(lcp *LCP, d []*big.Rat) (*tableau, []*big.Int, error) {
+func createTableau(lcp *LCP, d []*big.Rat, kind StorageKind) (*tableau, []*big.Int, error) {
 
 	err := checkInputs(lcp.q, d)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	tableau := newTableau(lcp.n)
-	scfa := make([]*big.Int, tableau.ncols)
+	tableau, scfa := fillTableau(lcp, d, kind)
+	return tableau, scfa, nil
+}
+
+// fillTableau is createTableau without checking that Lemke can start.
+func fillTableau(lcp *LCP, d []*big.Rat, kind StorageKind) (*tableau, []*big.Int) {
+	return newTableauTemplate(lcp, kind).fill(d)
+}
+
+/*
+ * tableauTemplate
+ * ================================================================
+ * the cols of  M  and  q  of the initial tableau, scaled to integers.
+ * They do not depend on the covering vector, so many tableaus for
+ * different  d  can be filled from one template.
+ */
+type tableauTemplate struct {
+	lcp  *LCP
+	kind StorageKind
+	cols [][]*big.Int // cols 1..n+1 of the tableau
+	scfa []*big.Int   // their scale factors, scfa[0] is left to  d
+}
+
+func newTableauTemplate(lcp *LCP, kind StorageKind) *tableauTemplate {
 
-	for j := 0; j < tableau.ncols; j++ {
+	T := &tableauTemplate{
+		lcp:  lcp,
+		kind: kind,
+		cols: make([][]*big.Int, lcp.n+1),
+		scfa: make([]*big.Int, lcp.n+2),
+	}
+
+	for j := 1; j <= lcp.n+1; j++ {
 
 		fnVec := func(i int) *big.Rat {
-			if j == 0 {
-				return d[i]
-			}
 			if j == lcp.n+1 {
 				return lcp.q[i]
 			}
@@ -133,31 +339,52 @@ func createTableau(lcp *LCP, d []*big.Rat) (*tableau, []*big.Int, error) {
 		}
 
 		// TODO: store scaleFactor on tableauVariable struct?
-		scaleFactor := computeScaleFactor(lcp.n, fnVec)
-		scfa[j] = scaleFactor
+		T.scfa[j] = computeScaleFactor(lcp.n, fnVec)
+		T.cols[j-1] = scaleColumn(lcp.n, fnVec, T.scfa[j])
+	}
+	return T
+}
+
+/* cols 0..n of  A  contain LHS cobasic cols of  Ax = b     */
+/* where the system is here         -Iw + dz_0 + Mz = -q    */
+/* cols of  q  will be negated after first min ratio test   */
+/* A[i][j] = num * (scfa[j] / den),  fraction is integral       */
+func scaleColumn(n int, vec func(i int) *big.Rat, scaleFactor *big.Int) []*big.Int {
 
-		for i := 0; i < tableau.nrows; i++ {
+	col := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		rat := vec(i)
+		value := new(big.Int).Mul(rat.Num(), scaleFactor)
+		col[i] = value.Div(value, rat.Denom())
+	}
+	return col
+}
 
-			rat := fnVec(i)
+// fill is a new tableau for the covering vector  d  and its scale
+// factors.
+func (T *tableauTemplate) fill(d []*big.Rat) (*tableau, []*big.Int) {
 
-			/* cols 0..n of  A  contain LHS cobasic cols of  Ax = b     */
-			/* where the system is here         -Iw + dz_0 + Mz = -q    */
-			/* cols of  q  will be negated after first min ratio test   */
-			/* A[i][j] = num * (scfa[j] / den),  fraction is integral       */
+	n := T.lcp.n
+	tableau := newTableauWith(n, newStorage(T.lcp, d, T.kind))
+	scfa := append([]*big.Int{}, T.scfa...)
+
+	fnVec := func(i int) *big.Rat {
+		return d[i]
+	}
+	scfa[0] = computeScaleFactor(n, fnVec)
+	for i, value := range scaleColumn(n, fnVec, scfa[0]) {
+		tableau.set(i, 0, value)
+	}
 
-			value := new(big.Int).Mul(rat.Num(), scaleFactor)
-			value.Div(value, rat.Denom())
-			tableau.set(i, j, value)
+	for j := 1; j <= n+1; j++ {
+		for i, value := range T.cols[j-1] {
+			tableau.set(i, j, new(big.Int).Set(value))
 		}
 	}
 
-	return tableau, scfa, nil
+	return tableau, scfa
 }
 
-/*
- * compute lcm  of denominators for  col  j  of  A
- * Necessary for converting fractions to integers and back again
- */
 func computeScaleFactor(n int, vec func(i int) *big.Rat) *big.Int {
 
 	lcm := big.NewInt(1)
@@ -178,20 +405,24 @@ func computeScaleFactor(n int, vec func(i int) *big.Rat) *big.Int {
  */
 func checkInputs(q []*big.Rat, d []*big.Rat) error {
 
+	if len(d) != len(q) {
+		return fmt.Errorf("%w: covering vector has %d entries but q has %d", ErrDimension, len(d), len(q))
+	}
+
 	isQPos := true
 	for i := 0; i < len(q); i++ {
 		if d[i].Sign() < 0 {
-			return fmt.Errorf("Covering vector  d[%d] = %s negative. Cannot start Lemke.", i+1, d[i])
+			return fmt.Errorf("%w: d[%d] = %s negative, cannot start Lemke", ErrBadCoveringVector, i+1, d[i])
 		} else if q[i].Sign() < 0 {
 			isQPos = false
 			if d[i].Sign() == 0 {
-				return fmt.Errorf("Covering vector  d[%d] = 0  where  q[%d] = %s  is negative. Cannot start Lemke.", i+1, i+1, q[i])
+				return fmt.Errorf("%w: d[%d] = 0 where q[%d] = %s is negative, cannot start Lemke", ErrBadCoveringVector, i+1, i+1, q[i])
 			}
 		}
 	}
 
 	if isQPos {
-		return errors.New("No need to start Lemke since  q>=0. Trivial solution  z=0.")
+		return ErrTrivialSolution
 	}
 
 	return nil
@@ -13,7 +13,7 @@ func TestLemkeRun2(t *testing.T) {
 	q := ints2rats([]int{-1, -1})
 	d := ints2rats([]int{2, 1})
 
-	lcp := NewLCP(M, q)
+	lcp := newTestLCP(t, M, q)
 
 	z, err := Solve(lcp, d)
 	assert.Nil(t, err)
@@ -33,7 +33,7 @@ func TestLemkeRun3(t *testing.T) {
 	q := ints2rats([]int{-3, 6, -1})
 	d := ints2rats([]int{1, 1, 1})
 
-	lcp := NewLCP(M, q)
+	lcp := newTestLCP(t, M, q)
 
 	z, err := Solve(lcp, d)
 	assert.Nil(t, err)
@@ -52,6 +52,14 @@ func TestLemkeRun3(t *testing.T) {
 	assert.Equal(t, int64(3), z[2].Num().Int64())
 }
 
+func newTestLCP(t *testing.T, M []*big.Rat, q []*big.Rat) *LCP {
+	lcp, err := NewLCP(M, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lcp
+}
+
 func ints2rats(ints []int) []*big.Rat {
 	rats := make([]*big.Rat, len(ints))
 	for i := 0; i < len(ints); i++ {
@@ -2,6 +2,16 @@ package lemke
 
 import "fmt"
 
+// ratioTableau is what the lexicographic ratio test needs from a tableau.
+// Signs and ratios must be those of the integer tableau, i.e. of the
+// entries before dividing by the determinant.
+type ratioTableau interface {
+	variables() *tableauVariables
+	sign(row int, col int) int
+	ratioTest(rowA int, rowB int, colA int, colB int) int
+	rhsCol() int
+}
+
 /*
  * minVar
  * ===========================================================
@@ -12,74 +22,53 @@ import "fmt"
  * basis, but the lex-minratio test is performed fully,
  * so the returned value might not be the index of  z0
  */
-func lexminratio(tableau *tableau, enter *tableauVariable) (*tableauVariable, bool, error) {
+func lexminratio(tableau ratioTableau, enter *tableauVariable) (*tableauVariable, bool, error) {
+	leave, z0leave, _, err := minratio(tableau, enter, Lexicographic)
+	return leave, z0leave, err
+}
 
-	var err error
-	z0leave := false
-	leaveCandidateRows := make([]int, 0, tableau.vars.n)
+/*
+ * minratio
+ * ================================================================
+ * minimum ratio test on the rhs among the rows with a positive entry
+ * in the entering column, any remaining tie is broken by  rule.
+ * @return also the number of rows tied with the leaving one
+ */
+func minratio(tableau ratioTableau, enter *tableauVariable, rule PivotRule) (*tableauVariable, bool, int, error) {
+
+	vars := tableau.variables()
+	leaveCandidateRows := make([]int, 0, vars.n)
 
 	if enter.isBasic() {
-		panic(fmt.Sprintf("Variable %v is already in basis. Must be cobasic to enter.", enter))
+		return nil, false, 0, fmt.Errorf("%w: variable %v is already in basis, must be cobasic to enter", ErrBadPivot, enter)
 	}
 
 	enterCol := enter.col()
 
 	// start with  leavecand = { i | A[i][col] > 0 }
-	for i := 0; i < tableau.vars.n; i++ {
-		if tableau.entry(i, enterCol).Sign() > 0 {
+	for i := 0; i < vars.n; i++ {
+		if tableau.sign(i, enterCol) > 0 {
 			leaveCandidateRows = append(leaveCandidateRows, i)
 		}
 	}
 
 	if len(leaveCandidateRows) == 0 {
-		return enter, z0leave, fmt.Errorf("Ray termination when trying to enter %s", enter)
+		return enter, false, 0, fmt.Errorf("%w when trying to enter %s", ErrRayTermination, enter)
 	}
 
-	/*else if (numcand == 1) {
-		RecordStats(0, numcand);
-		z0leave = IsLeavingRowZ0(leavecand[0]);
-	}*/
-
-	leaveCandidateRows, z0leave = processCandidates(tableau, enterCol, leaveCandidateRows)
-
-	return tableau.vars.fromRow(leaveCandidateRows[0]), z0leave, err
-}
-
-/*
- * processCandidates
- * ================================================================
- * as long as there is more than one leaving candidate perform
- * a minimum ratio test for the columns of  j  in RHS, W(1),... W(n)
- * in the tableau.  That test has an easy known result if
- * the test column is basic or equal to the entering variable.
- */
-func processCandidates(tableau *tableau, enterCol int, leaveCandidateRows []int) ([]int, bool) {
-
-	leaveCandidateRows = minRatioTest(tableau, enterCol, tableau.ncols-1, leaveCandidateRows)
-	z0leave := checkForZ0(tableau.vars, leaveCandidateRows)
+	leaveCandidateRows = minRatioTest(tableau, enterCol, tableau.rhsCol(), leaveCandidateRows)
+	z0leave := checkForZ0(vars, leaveCandidateRows)
 	/* alternative, to force z0 leaving the basis:
 	* return whichvar[leavecand[i]];
 	 */
 
-	for j := 1; len(leaveCandidateRows) > 1; j++ {
-		//if j >= A.RHS() {                                             /* impossible, perturbed RHS should have full rank */
-		//    throw new RuntimeException("lex-minratio test failed"); //TODO
-		//}
-
-		wj := tableau.vars.w(j)
-		fmt.Printf("Checking leave candidate %s\n", wj)
-		if wj.isBasic() { /* testcol < 0: W(j) basic, Eliminate its row from leavecand */
-			fmt.Printf("%s is basic... removing from candidate\n", wj)
-			leaveCandidateRows = remove(leaveCandidateRows, wj.row())
-		} else { // not a basic testcolumn: perform minimum ratio tests
-			testCol := wj.col()      /* since testcol is the  jth  unit column                    */
-			if testCol != enterCol { /* otherwise nothing will change */
-				leaveCandidateRows = minRatioTest(tableau, enterCol, testCol, leaveCandidateRows)
-			}
-		}
+	ties := len(leaveCandidateRows) - 1
+	leaveRow := leaveCandidateRows[0]
+	if ties > 0 {
+		leaveRow = rule.BreakTie(ratioView{tableau}, enterCol, leaveCandidateRows)
 	}
 
-	return leaveCandidateRows, z0leave
+	return vars.fromRow(leaveRow), z0leave, ties, nil
 }
 
 func remove(slice []int, value int) []int {
@@ -102,14 +91,20 @@ func checkForZ0(vars *tableauVariables, leaveCandidateRows []int) bool {
 	return false
 }
 
-func minRatioTest(tableau *tableau, enterCol int, testCol int, candidateRows []int) []int {
+func minRatioTest(tableau ratioTableau, enterCol int, testCol int, candidateRows []int) []int {
+	return minRatioRows(ratioView{tableau}, enterCol, testCol, candidateRows)
+}
+
+// minRatioRows keeps the rows of candidateRows with the least ratio
+// A[row][testCol] / A[row][enterCol].
+func minRatioRows(t RatioTable, enterCol int, testCol int, candidateRows []int) []int {
 
 	numCandidates := 0
 	for i := 1; i < len(candidateRows); i++ { /* investigate remaining candidates                  */
 
 		// sign of  A[l_0,t] / A[l_0,col] - A[l_i,t] / A[l_i,col]
 		// note only positive entries of entering column considered
-		sgn := tableau.ratioTest(
+		sgn := t.RatioTest(
 			candidateRows[0], candidateRows[i], enterCol, testCol)
 
 		if sgn == 0 {
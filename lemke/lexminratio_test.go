@@ -1,6 +1,7 @@
 package lemke
 
 import (
+	"errors"
 	"log"
 	"math/big"
 	"testing"
@@ -40,15 +41,11 @@ func TestLexMinVar(t *testing.T) {
 	assert.Equal(t, false, z0leave, "z0 is not leaving")
 	assert.Nil(t, err, "No ray termination")
 
-	// TODO: expect panic...
-	/*_, _, err = lexminratio(a, vars, vars.w(1))
-	assert.NotNil(t, err, "Should be an error")
-	assert.Equal(t, "Variable w1 is already in basis. Must be cobasic to enter.", err.Error())
+	_, _, err = lexminratio(a, a.vars.w(1))
+	assert.True(t, errors.Is(err, ErrBadPivot), "w1 is already in basis")
 
-	_, _, err = lexminratio(a, vars, vars.w(2))
-	assert.NotNil(t, err, "Should be an error")
-	assert.Equal(t, "Variable w2 is already in basis. Must be cobasic to enter.", err.Error())
-	*/
+	_, _, err = lexminratio(a, a.vars.w(2))
+	assert.True(t, errors.Is(err, ErrBadPivot), "w2 is already in basis")
 }
 
 // TODO: make this a benchmark test...
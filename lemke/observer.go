@@ -0,0 +1,132 @@
+package lemke
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/megesdal/matrixprinter"
+)
+
+// PivotStep describes a single pivot performed while solving an LCP.
+type PivotStep struct {
+	Count int      // number of pivots so far, this one included
+	Enter Variable // variable that entered the basis
+	Leave Variable // variable that left the basis
+	Row   int      // tableau row of the pivot element
+	Col   int      // tableau col of the pivot element
+	Det   *big.Int // determinant after the pivot
+	Z0    *big.Rat // value of z0 after the pivot
+	Ties  int      // rows tied with Leave in the ratio test, 0 unless degenerate
+
+	// Tableau is a copy of the tableau after the pivot.  It is only filled
+	// in when Options.Snapshots is set since copying is not cheap.
+	Tableau *Snapshot
+}
+
+// Observer is told about every pivot of a Lemke run.  Observers are called
+// synchronously from the pivoting loop so they should return quickly.
+type Observer interface {
+	Pivot(step *PivotStep)
+}
+
+// ObserverFunc adapts an ordinary function to the Observer interface.
+type ObserverFunc func(step *PivotStep)
+
+// Pivot calls f(step).
+func (f ObserverFunc) Pivot(step *PivotStep) {
+	f(step)
+}
+
+// Snapshot is a copy of the integer tableau with the labels of its basic
+// (row) and cobasic (column) variables.  The last column is the rhs.
+// Values of the basic variables are the rhs entries divided by Det,
+// before undoing the column scale factors.
+type Snapshot struct {
+	Basis   []Variable
+	Cobasis []Variable
+	Entries [][]*big.Int
+	Det     *big.Int
+}
+
+// Entry returns the entry at row, col of the snapshot.
+func (s *Snapshot) Entry(row int, col int) *big.Int {
+	return s.Entries[row][col]
+}
+
+func (s *Snapshot) String() string {
+
+	table := matrixprinter.NewTable()
+	table.Append("")
+	for _, v := range s.Cobasis {
+		table.Append(v.String())
+	}
+	table.Append("rhs")
+	table.EndRow()
+
+	for i, v := range s.Basis {
+		table.Append(v.String())
+		for _, entry := range s.Entries[i] {
+			table.Append(entry.String())
+		}
+		table.EndRow()
+	}
+
+	var buffer bytes.Buffer
+	table.Print(&buffer)
+	return buffer.String()
+}
+
+func (A *tableau) snapshot() *Snapshot {
+
+	s := &Snapshot{
+		Basis:   make([]Variable, A.nrows),
+		Cobasis: make([]Variable, A.ncols-1),
+		Entries: make([][]*big.Int, A.nrows),
+		Det:     new(big.Int).Set(A.det),
+	}
+
+	for j := 0; j < A.ncols-1; j++ {
+		s.Cobasis[j] = A.vars.fromCol(j).variable()
+	}
+
+	for i := 0; i < A.nrows; i++ {
+		s.Basis[i] = A.vars.fromRow(i).variable()
+		s.Entries[i] = make([]*big.Int, A.ncols)
+		for j := 0; j < A.ncols; j++ {
+			s.Entries[i][j] = new(big.Int).Set(A.entry(i, j))
+		}
+	}
+	return s
+}
+
+// notify tells the observer, if any, about the pivot just performed.
+func (opts *Options) notify(T lemkeTableau, count int, enter *tableauVariable, leave *tableauVariable, row int, col int, ties int) {
+
+	if opts.Observer == nil {
+		return
+	}
+
+	step := T.pivotStep(count, enter, leave, row, col, opts.Snapshots)
+	step.Ties = ties
+	opts.Observer.Pivot(step)
+}
+
+func (T scaledTableau) pivotStep(count int, enter *tableauVariable, leave *tableauVariable, row int, col int, snapshot bool) *PivotStep {
+
+	A := T.tableau
+	den := new(big.Int).Mul(A.det, T.scaleFactors[A.rhsCol()])
+	step := &PivotStep{
+		Count: count,
+		Enter: enter.variable(),
+		Leave: leave.variable(),
+		Row:   row,
+		Col:   col,
+		Det:   new(big.Int).Set(A.det),
+		Z0:    result(A.vars.z(0), den, A, T.scaleFactors),
+	}
+
+	if snapshot {
+		step.Tableau = A.snapshot()
+	}
+	return step
+}
@@ -0,0 +1,71 @@
+package lemke
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserverSeesEveryPivot(t *testing.T) {
+
+	M := ints2rats([]int{2, 1, 1, 3})
+	q := ints2rats([]int{-1, -1})
+	d := ints2rats([]int{2, 1})
+
+	var steps []*PivotStep
+	opts := &Options{
+		Observer: ObserverFunc(func(step *PivotStep) {
+			steps = append(steps, step)
+		}),
+		Snapshots: true,
+	}
+
+	z, err := SolveWithOptions(newTestLCP(t, M, q), d, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(z))
+
+	assert.Equal(t, 3, len(steps))
+	for i, step := range steps {
+		assert.Equal(t, i+1, step.Count)
+		assert.NotNil(t, step.Tableau)
+		assert.Equal(t, step.Enter, step.Tableau.Basis[step.Row])
+		assert.Equal(t, step.Leave, step.Tableau.Cobasis[step.Col])
+		assert.Equal(t, 0, step.Det.Cmp(step.Tableau.Det))
+	}
+
+	first := steps[0]
+	assert.Equal(t, Z(0), first.Enter)
+	assert.Equal(t, "1", first.Z0.RatString())
+
+	last := steps[len(steps)-1]
+	assert.Equal(t, Z(0), last.Leave)
+	assert.Equal(t, 0, last.Z0.Sign())
+}
+
+func TestObserverWithoutSnapshots(t *testing.T) {
+
+	M := ints2rats([]int{0, -1, 2, 2, 0, -2, -1, 1, 0})
+	q := ints2rats([]int{-3, 6, -1})
+	d := ints2rats([]int{1, 1, 1})
+
+	count := 0
+	opts := &Options{
+		MaxPivots: 2,
+		Observer: ObserverFunc(func(step *PivotStep) {
+			count++
+			assert.Nil(t, step.Tableau)
+		}),
+	}
+
+	_, err := SolveWithOptions(newTestLCP(t, M, q), d, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestVariableString(t *testing.T) {
+	assert.Equal(t, "z0", Z(0).String())
+	assert.Equal(t, "z3", Z(3).String())
+	assert.Equal(t, "w3", W(3).String())
+	assert.Equal(t, 3, W(3).Index())
+	assert.Equal(t, false, W(1).IsZ())
+}
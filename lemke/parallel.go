@@ -0,0 +1,48 @@
+package lemke
+
+import "sync"
+
+// minRowsPerWorker keeps goroutines from being started for a handful of
+// rows, where they cost more than they save.
+const minRowsPerWorker = 8
+
+/*
+ * forRows calls  update(worker, lo, hi)  for consecutive ranges of rows
+ * covering  0..nrows-1, each on its own goroutine if there are several
+ * workers.  Rows of a pivot only read the pivot row and each write
+ * their own, so the result does not depend on the number of workers;
+ * anything else a worker produces must be kept per worker and merged
+ * by the caller in worker order.  Returns the number of ranges.
+ */
+func forRows(nrows int, workers int, update func(worker int, lo int, hi int)) int {
+
+	workers = rowWorkers(nrows, workers)
+	if workers == 1 {
+		update(0, 0, nrows)
+		return 1
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo, hi := w*nrows/workers, (w+1)*nrows/workers
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			update(w, lo, hi)
+		}(w)
+	}
+	wg.Wait()
+	return workers
+}
+
+// rowWorkers is the number of ranges forRows splits  nrows  into for
+// workers, at least one however few workers are asked for.
+func rowWorkers(nrows int, workers int) int {
+	if most := nrows / minRowsPerWorker; workers > most {
+		workers = most
+	}
+	if workers < 1 {
+		return 1
+	}
+	return workers
+}
@@ -0,0 +1,44 @@
+package lemke
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForRowsCoversEveryRowOnce(t *testing.T) {
+
+	for _, workers := range []int{-2, 0, 1, 3, 8, 100} {
+		var mu sync.Mutex
+		seen := make([]int, 50)
+		forRows(len(seen), workers, func(_ int, lo int, hi int) {
+			mu.Lock()
+			defer mu.Unlock()
+			for i := lo; i < hi; i++ {
+				seen[i]++
+			}
+		})
+		for i := range seen {
+			assert.Equal(t, 1, seen[i], "workers %d row %d", workers, i)
+		}
+	}
+}
+
+func TestParallelPivotMatchesSerial(t *testing.T) {
+
+	lcp, d := bimatrixTestLCP(t, 20, 5, 1)
+	for _, kind := range []StorageKind{DenseStorage, SparseStorage, Int64Storage} {
+		expected, err := SolveResult(lcp, d, &Options{Storage: kind})
+		assert.Nil(t, err)
+
+		for _, workers := range []int{-2, 2, 3, 7} {
+			res, err := SolveResult(lcp, d, &Options{Storage: kind, Workers: workers})
+			assert.Nil(t, err)
+			assert.Equal(t, solutionKey(expected.Z), solutionKey(res.Z))
+			assert.Equal(t, expected.Basis, res.Basis)
+			assert.Equal(t, expected.Pivots, res.Pivots)
+			assert.Equal(t, expected.FillIn, res.FillIn)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package lemke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Breakpoint is a vertex of the solution path of the parametric LCP
+// w = Mz + q + t r, where the path may change direction.
+type Breakpoint struct {
+	T     *big.Rat
+	Z     []*big.Rat // z1..zn
+	W     []*big.Rat // w1..wn, equal to Mz + q + t r
+	Basis []Variable // basic variable of each tableau row, z0 is t
+}
+
+// Path is the piecewise linear solution path of the parametric LCP
+// traced by ParametricLemke.  Segment k runs from Breakpoints[k] to
+// Breakpoints[k+1], or along Ray if it is the last one and Status is
+// RayTermination.  Along it Enter[k] grows from zero, the variables of
+// Breakpoints[k].Basis change linearly with it and all others stay zero.
+type Path struct {
+	// Status is RayTermination if the last segment is unbounded, the
+	// usual end with t growing without bound, and Solved if the path
+	// came back to t = 0 at another solution.  PivotLimit and Canceled
+	// are as for Lemke.
+	Status Status
+
+	Breakpoints []Breakpoint
+	Enter       []Variable // z0, i.e. t, on the first segment
+
+	// Ray is the direction (t, z1..zn) of the last segment on
+	// RayTermination.  If its t is zero the solutions for the t of the
+	// last breakpoint are unbounded.
+	Ray []*big.Rat
+}
+
+// ParametricLemke is ParametricLemkeContext without a context.
+func ParametricLemke(lcp *LCP, r []*big.Rat, start []Variable, opts *Options) (*Path, error) {
+	return ParametricLemkeContext(context.Background(), lcp, r, start, opts)
+}
+
+/*
+ * ParametricLemkeContext
+ * ================================================================
+ * traces the solutions of  w = Mz + q + t r  from  t = 0.  In the
+ * Lemke tableau with covering vector  r  the parameter  t  is  z0,
+ * and every point on a path of almost complementary bases solves the
+ * LCP for its value of  z0, so from the complementary basis  start,
+ * which must solve the LCP at  t = 0, the path is Lemke's algorithm
+ * with  z0  entering first.  For a P-matrix  t  only grows along it,
+ * otherwise it may turn back.  A nil  start  is the all w basis, which
+ * needs  q >= 0.  Of opts MaxPivots, Observer, PivotRule, Storage and
+ * Workers apply.  Once ctx is done the Path so far is returned with a
+ * *CanceledError.
+ */
+func ParametricLemkeContext(ctx context.Context, lcp *LCP, r []*big.Rat, start []Variable, opts *Options) (*Path, error) {
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	rule, err := opts.pivotRule(lcp.n)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r) != lcp.n {
+		return nil, fmt.Errorf("%w: r has %d entries, the LCP has %d rows", ErrDimension, len(r), lcp.n)
+	}
+
+	if start == nil {
+		start = make([]Variable, lcp.n)
+		for i := range start {
+			start[i] = W(i + 1)
+		}
+	}
+
+	tableau, scaleFactors := fillTableau(lcp, r, opts.Storage)
+	tableau.workers = opts.Workers
+	tableau.negateCol(tableau.rhsCol())
+
+	if _, err := tableau.factorize(start, nil); err != nil {
+		return nil, err
+	}
+	if !tableau.vars.complementary() {
+		return nil, fmt.Errorf("%w: start basis %v is not complementary", ErrBadBasis, start)
+	}
+	if !tableau.feasible() {
+		return nil, fmt.Errorf("%w: start basis %v is no solution at t = 0", ErrBadBasis, start)
+	}
+
+	// the ratio test needs det > 0, which only the all w basis lacks;
+	// negating det and every column leaves the values alone
+	if tableau.det.Sign() < 0 {
+		for j := 0; j < tableau.ncols; j++ {
+			tableau.negateCol(j)
+		}
+		tableau.det.Neg(tableau.det)
+	}
+
+	T := scaledTableau{tableau, scaleFactors}
+	path := &Path{Breakpoints: []Breakpoint{breakpoint(T)}}
+
+	enter := tableau.vars.z(0)
+	leave, z0leave, ties, err := minratio(tableau, enter, rule)
+	if errors.Is(err, ErrRayTermination) {
+		path.Status = RayTermination
+		path.Enter = append(path.Enter, enter.variable())
+		path.Ray = T.ray(enter)
+		return path, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	s := newSolver(T, opts, enter, leave, z0leave, ties)
+	for !s.Done() {
+		if ctx.Err() != nil {
+			res := s.Result()
+			res.Status = Canceled
+			path.Status = Canceled
+			return path, &CanceledError{Result: res, Err: ctx.Err()}
+		}
+
+		path.Enter = append(path.Enter, s.enter.variable())
+		if err := s.Step(); err != nil && !errors.Is(err, ErrRayTermination) {
+			return nil, err
+		}
+		path.Breakpoints = append(path.Breakpoints, breakpoint(T))
+	}
+
+	path.Status = s.status
+	if s.status == RayTermination {
+		path.Enter = append(path.Enter, s.enter.variable())
+		path.Ray = T.ray(s.enter)
+	}
+	return path, nil
+}
+
+// breakpoint is the current basic solution with t for z0.
+func breakpoint(T scaledTableau) Breakpoint {
+	res := T.result(Running, 0)
+	return Breakpoint{T: res.Z0, Z: res.Z, W: res.W, Basis: res.Basis}
+}
+
+// At is z1..zn on the first segment of the path through t, nil if
+// there is none.
+func (p *Path) At(t *big.Rat) []*big.Rat {
+
+	for k := 0; k+1 < len(p.Breakpoints); k++ {
+		from, to := p.Breakpoints[k], p.Breakpoints[k+1]
+
+		span := new(big.Rat).Sub(to.T, from.T)
+		offset := new(big.Rat).Sub(t, from.T)
+		if span.Sign() == 0 {
+			if offset.Sign() == 0 {
+				return copyRats(from.Z)
+			}
+			continue
+		}
+
+		lambda := offset.Quo(offset, span)
+		if lambda.Sign() < 0 || lambda.Cmp(big.NewRat(1, 1)) > 0 {
+			continue
+		}
+
+		z := make([]*big.Rat, len(from.Z))
+		for i := range z {
+			z[i] = new(big.Rat).Sub(to.Z[i], from.Z[i])
+			z[i].Add(z[i].Mul(z[i], lambda), from.Z[i])
+		}
+		return z
+	}
+
+	if p.Status != RayTermination {
+		return nil
+	}
+
+	last := p.Breakpoints[len(p.Breakpoints)-1]
+	offset := new(big.Rat).Sub(t, last.T)
+	if p.Ray[0].Sign() == 0 {
+		if offset.Sign() == 0 {
+			return copyRats(last.Z)
+		}
+		return nil
+	}
+
+	lambda := offset.Quo(offset, p.Ray[0])
+	if lambda.Sign() < 0 {
+		return nil
+	}
+
+	z := make([]*big.Rat, len(last.Z))
+	for i := range z {
+		z[i] = new(big.Rat).Mul(p.Ray[i+1], lambda)
+		z[i].Add(z[i], last.Z[i])
+	}
+	return z
+}
+
+func copyRats(rats []*big.Rat) []*big.Rat {
+	copied := make([]*big.Rat, len(rats))
+	for i, rat := range rats {
+		copied[i] = new(big.Rat).Set(rat)
+	}
+	return copied
+}
@@ -0,0 +1,125 @@
+package lemke
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// shiftedLCP is the LCP with q + t r for q.
+func shiftedLCP(t *testing.T, lcp *LCP, r []*big.Rat, at *big.Rat) *LCP {
+	q := make([]*big.Rat, lcp.N())
+	for i := range q {
+		q[i] = new(big.Rat).Mul(r[i], at)
+		q[i].Add(q[i], lcp.Q(i))
+	}
+	return newTestLCP(t, lcp.m, q)
+}
+
+func TestParametricLemke(t *testing.T) {
+
+	// w2 = 1 - 2t  drops to zero at t = 1/2, then z2 = t - 1/2
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 2}), ints2rats([]int{1, 1}))
+	r := ints2rats([]int{-1, -2})
+
+	path, err := ParametricLemke(lcp, r, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, RayTermination, path.Status)
+	assert.Equal(t, 2, len(path.Breakpoints))
+	assert.Equal(t, []Variable{Z(0), Z(2)}, path.Enter)
+	assert.Equal(t, "1/2", path.Breakpoints[1].T.RatString())
+	assert.Equal(t, []string{"1/2", "0"}, ratStrings(path.Breakpoints[1].W))
+
+	assert.Equal(t, []string{"0", "0"}, ratStrings(path.At(big.NewRat(1, 4))))
+	assert.Equal(t, []string{"0", "3/2"}, ratStrings(path.At(big.NewRat(2, 1))))
+	assert.Nil(t, path.At(big.NewRat(-1, 1)))
+}
+
+func TestParametricLemkeTurnsBack(t *testing.T) {
+
+	// w = 1 - z - t:  w leaves at t = 1, then z grows as t falls to 0
+	lcp := newTestLCP(t, ints2rats([]int{-1}), ints2rats([]int{1}))
+
+	path, err := ParametricLemke(lcp, ints2rats([]int{-1}), nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, path.Status)
+	assert.Equal(t, []Variable{Z(0), Z(1)}, path.Enter)
+
+	var ts []string
+	for _, point := range path.Breakpoints {
+		ts = append(ts, point.T.RatString())
+	}
+	assert.Equal(t, []string{"0", "1", "0"}, ts)
+	assert.Equal(t, []string{"1"}, ratStrings(path.Breakpoints[2].Z))
+	assert.Equal(t, []Variable{Z(1)}, path.Breakpoints[2].Basis)
+}
+
+func TestParametricLemkePMatrix(t *testing.T) {
+
+	for seed := int64(1); seed <= 5; seed++ {
+		lcp, d := randomTestLCP(t, 6, seed)
+		res, err := SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+
+		rnd := rand.New(rand.NewSource(seed))
+		r := make([]*big.Rat, lcp.N())
+		for i := range r {
+			r[i] = big.NewRat(int64(rnd.Intn(21)-10), 1)
+		}
+
+		path, err := ParametricLemke(lcp, r, res.Basis, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, RayTermination, path.Status)
+		assert.True(t, path.Ray[0].Sign() > 0)
+
+		for k, point := range path.Breakpoints {
+			if k > 0 {
+				assert.True(t, point.T.Cmp(path.Breakpoints[k-1].T) >= 0)
+			}
+			assertSolves(t, shiftedLCP(t, lcp, r, point.T), point.Z)
+		}
+
+		// the solution for each t is unique, so the path must have it
+		last := path.Breakpoints[len(path.Breakpoints)-1].T
+		for _, at := range []*big.Rat{big.NewRat(1, 3), new(big.Rat).Quo(last, big.NewRat(2, 1)), new(big.Rat).Add(last, big.NewRat(1, 1))} {
+			shifted := shiftedLCP(t, lcp, r, at)
+			z := path.At(at)
+			assertSolves(t, shifted, z)
+
+			expected, err := Solve(shifted, d)
+			if errors.Is(err, ErrTrivialSolution) {
+				expected = make([]*big.Rat, lcp.N())
+				for i := range expected {
+					expected[i] = new(big.Rat)
+				}
+			}
+			assert.Equal(t, solutionKey(expected), solutionKey(z))
+		}
+	}
+}
+
+func TestParametricLemkeErrors(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 2}), ints2rats([]int{-1, 1}))
+
+	_, err := ParametricLemke(lcp, ints2rats([]int{1}), nil, nil)
+	assert.True(t, errors.Is(err, ErrDimension))
+
+	// the all w basis has w1 = -1
+	_, err = ParametricLemke(lcp, ints2rats([]int{1, 1}), nil, nil)
+	assert.True(t, errors.Is(err, ErrBadBasis))
+
+	_, err = ParametricLemke(lcp, ints2rats([]int{1, 1}), []Variable{Z(1), Z(1)}, nil)
+	assert.True(t, errors.Is(err, ErrBadBasis))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	path, err := ParametricLemkeContext(ctx, lcp, ints2rats([]int{1, -1}), []Variable{Z(1), W(2)}, nil)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, Canceled, path.Status)
+	assert.Equal(t, 1, len(path.Breakpoints))
+}
@@ -0,0 +1,154 @@
+package lemke
+
+import "fmt"
+
+// PivotRule picks the leaving variable when the minimum ratio test on the
+// rhs leaves more than one candidate, i.e. at a degenerate pivot.  With a
+// nondegenerate LCP the rule is never asked.
+type PivotRule interface {
+	// BreakTie returns one of the rows in tied, all of which have the
+	// same minimum ratio rhs / A[row][col] for the entering column col.
+	BreakTie(t RatioTable, col int, tied []int) int
+}
+
+// RatioTable is the read-only view of a tableau given to a PivotRule.
+// Entries are never exposed directly since the float tableau stores them
+// divided by the determinant, only their signs and ratios are.
+type RatioTable interface {
+	// Rows is the number of rows, n for an LCP of size n.
+	Rows() int
+	// Basic is the basic variable of row.
+	Basic(row int) Variable
+	// Row is the row of v, or -1 if v is cobasic.
+	Row(v Variable) int
+	// Col is the column of v, or -1 if v is basic.
+	Col(v Variable) int
+	// RatioTest is the sign of A[a][test]/A[a][col] - A[b][test]/A[b][col].
+	RatioTest(a int, b int, col int, test int) int
+}
+
+// Lexicographic is the lexicographic minimum ratio test of lemke.c, the
+// default.  It never cycles.
+var Lexicographic PivotRule = Perturbation(nil)
+
+// LeastIndex is Bland's rule: the tied basic variable with the least
+// index leaves, where z0 < z1 < ... < zn < w1 < ... < wn.  It is cheap but
+// Lemke's algorithm may cycle under it, so set Options.MaxPivots.
+var LeastIndex PivotRule = leastIndex{}
+
+type leastIndex struct{}
+
+func (leastIndex) BreakTie(t RatioTable, col int, tied []int) int {
+	best := tied[0]
+	for _, row := range tied[1:] {
+		if less(t.Basic(row), t.Basic(best)) {
+			best = row
+		}
+	}
+	return best
+}
+
+func less(a Variable, b Variable) bool {
+	if a.IsZ() != b.IsZ() {
+		return a.IsZ()
+	}
+	return a.Index() < b.Index()
+}
+
+// Perturbation is the rule obtained by replacing q(i) with
+// q(i) + eps^order(i) for a symbolically small eps > 0, where order is a
+// permutation of 1..n; order[i-1] is the power of eps added to q(i).
+// Ties are then broken by minimum ratio tests on the columns of w in the
+// order of increasing power, which never cycles.  A nil order is
+// 1, 2, .., n, which is Lexicographic.
+type Perturbation []int
+
+// BreakTie performs the minimum ratio tests on the w columns in the order
+// of increasing power of eps.
+func (p Perturbation) BreakTie(t RatioTable, col int, tied []int) int {
+
+	wOrder, err := p.columns(t.Rows())
+	if err != nil { // rejected before the first pivot of a solve
+		wOrder, _ = Perturbation(nil).columns(t.Rows())
+	}
+
+	rows := append([]int(nil), tied...)
+	for k := 0; len(rows) > 1 && k < len(wOrder); k++ {
+		wj := W(wOrder[k])
+		if row := t.Row(wj); row >= 0 { /* W(j) basic, Eliminate its row from leavecand */
+			rows = remove(rows, row)
+		} else if testCol := t.Col(wj); testCol != col { /* otherwise nothing will change */
+			rows = minRatioRows(t, col, testCol, rows)
+		}
+	}
+	return rows[0]
+}
+
+// columns returns the w subscripts in the order their columns are tested.
+func (p Perturbation) columns(n int) ([]int, error) {
+
+	wOrder := make([]int, n)
+	if p == nil {
+		for i := range wOrder {
+			wOrder[i] = i + 1
+		}
+		return wOrder, nil
+	}
+
+	if len(p) != n {
+		return nil, fmt.Errorf("%w: perturbation of length %d for %d rows", ErrDimension, len(p), n)
+	}
+	for i, power := range p {
+		if power < 1 || power > n || wOrder[power-1] != 0 {
+			return nil, fmt.Errorf("%w: perturbation %v is not a permutation of 1..%d", ErrDimension, []int(p), n)
+		}
+		wOrder[power-1] = i + 1
+	}
+	return wOrder, nil
+}
+
+// pivotRule is the rule to use, checked against the size of the LCP.
+func (opts *Options) pivotRule(n int) (PivotRule, error) {
+	if opts.PivotRule == nil {
+		return Lexicographic, nil
+	}
+	if p, ok := opts.PivotRule.(Perturbation); ok {
+		if _, err := p.columns(n); err != nil {
+			return nil, err
+		}
+	}
+	return opts.PivotRule, nil
+}
+
+// ratioView is the RatioTable of a tableau, integer or float.
+type ratioView struct {
+	t ratioTableau
+}
+
+func (v ratioView) Rows() int {
+	return v.t.variables().n
+}
+
+func (v ratioView) Basic(row int) Variable {
+	return v.t.variables().fromRow(row).variable()
+}
+
+func (v ratioView) Row(x Variable) int {
+	tvar := v.t.variables().lookupVariable(x)
+	if tvar == nil || !tvar.isBasic() {
+		return -1
+	}
+	return tvar.row()
+}
+
+func (v ratioView) Col(x Variable) int {
+	tvar := v.t.variables().lookupVariable(x)
+	if tvar == nil || tvar.isBasic() {
+		return -1
+	}
+	return tvar.col()
+}
+
+func (v ratioView) RatioTest(a int, b int, col int, test int) int {
+	return v.t.ratioTest(a, b, col, test)
+}
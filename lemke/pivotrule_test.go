@@ -0,0 +1,81 @@
+package lemke
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// z0 enters with all three rows tied, each rule picks another w to leave
+func firstLeave(t *testing.T, rule PivotRule) (Variable, *Result) {
+
+	M := ints2rats([]int{1, 0, 0, 0, 1, 0, 0, 0, 1})
+	q := ints2rats([]int{-1, -1, -1})
+	d := ints2rats([]int{1, 1, 1})
+
+	var leave []Variable
+	observer := ObserverFunc(func(step *PivotStep) {
+		leave = append(leave, step.Leave)
+	})
+
+	res, err := SolveResult(newTestLCP(t, M, q), d, &Options{PivotRule: rule, Observer: observer})
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, res.Status)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "1", res.Z[i].RatString())
+	}
+	return leave[0], res
+}
+
+func TestPivotRuleLexicographic(t *testing.T) {
+
+	leave, res := firstLeave(t, nil)
+	assert.Equal(t, W(3), leave)
+	assert.Equal(t, 2, res.Ties[0])
+
+	leave, _ = firstLeave(t, Lexicographic)
+	assert.Equal(t, W(3), leave)
+}
+
+func TestPivotRuleLeastIndex(t *testing.T) {
+
+	leave, res := firstLeave(t, LeastIndex)
+	assert.Equal(t, W(1), leave)
+	assert.Equal(t, len(res.Ties), res.Pivots)
+	assert.True(t, res.DegeneratePivots() > 0)
+}
+
+func TestPivotRulePerturbation(t *testing.T) {
+
+	leave, _ := firstLeave(t, Perturbation{3, 2, 1})
+	assert.Equal(t, W(1), leave)
+
+	leave, _ = firstLeave(t, Perturbation{1, 3, 2})
+	assert.Equal(t, W(2), leave)
+}
+
+func TestPivotRuleBadPerturbation(t *testing.T) {
+
+	M := ints2rats([]int{1, 0, 0, 1})
+	q := ints2rats([]int{-1, -1})
+	d := ints2rats([]int{1, 1})
+
+	_, err := SolveResult(newTestLCP(t, M, q), d, &Options{PivotRule: Perturbation{1, 1}})
+	assert.True(t, errors.Is(err, ErrDimension))
+
+	_, err = SolveResult(newTestLCP(t, M, q), d, &Options{PivotRule: Perturbation{1}})
+	assert.True(t, errors.Is(err, ErrDimension))
+}
+
+func TestNondegenerateHasNoTies(t *testing.T) {
+
+	M := ints2rats([]int{2, 1, 1, 3})
+	q := ints2rats([]int{-1, -1})
+	d := ints2rats([]int{2, 1})
+
+	res, err := SolveResult(newTestLCP(t, M, q), d, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 0, 0}, res.Ties)
+	assert.Equal(t, 0, res.DegeneratePivots())
+}
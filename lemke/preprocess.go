@@ -0,0 +1,447 @@
+package lemke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Preprocessed is an LCP reduced by Preprocess together with what it
+// takes to map a solution of the reduced LCP back to the original one.
+type Preprocessed struct {
+	// LCP is the reduced LCP, nil if every variable was removed.
+	LCP *LCP
+
+	ForcedZero  int // variables with  w_i > 0  whatever z, so z_i = 0
+	ForcedBasic int // variables with  w_i = 0  in every solution
+	Duplicates  int // variables merged into an identical one
+
+	n        int
+	keep     []int      // original index of each variable of LCP
+	rowScale []*big.Rat // row i of LCP is row keep[i] times rowScale[i]
+	colScale []*big.Rat // z_keep[j] = z_j of LCP times colScale[j]
+	pivots   []forcedPivot
+}
+
+// forcedPivot solves row i of the LCP left when variable i was found
+// basic for z_i:
+//
+//	z_i = -(q + d_i z0 + sum_j row[j] z_j) / diag,  over the indices j of row
+//
+// for the covering vector d as eliminated up to then, and subtracts
+// col[r] times row i from each row r it was substituted into.
+type forcedPivot struct {
+	i    int
+	diag *big.Rat
+	q    *big.Rat
+	row  map[int]*big.Rat
+	col  map[int]*big.Rat
+}
+
+/*
+ * Preprocess
+ * ================================================================
+ * removes from  w = Mz + q  the variables whose complementarity status
+ * is forced, until there are no more:
+ *
+ *   q_i > 0  and  M_ij >= 0  for all j:  w_i > 0, so  z_i = 0  and
+ *   row and column i go;
+ *
+ *   q_i < 0  and  M_ij <= 0  for all j != i:  z_i > 0, so  w_i = 0
+ *   and  z_i  is eliminated by a principal pivot on  M_ii, which must
+ *   be positive, otherwise  w_i < 0  and the LCP has no solution;
+ *
+ *   rows, columns and q entries of i and j equal:  w_i = w_j  and
+ *   z_i, z_j  only matter through their sum, so j goes with  z_j = 0.
+ *
+ * Every solution of the reduced LCP maps to one of the original by
+ * Expand, and CoveringVector eliminates  z_i  from a covering vector
+ * too.  Last, each row of the reduced  (M q)  is scaled to integers
+ * without common divisor, and each column of M by the inverse of the
+ * gcd of its entries, which keeps the tableau entries small.
+ */
+func Preprocess(lcp *LCP) (*Preprocessed, error) {
+
+	n := lcp.n
+	M := make([][]*big.Rat, n)
+	q := make([]*big.Rat, n)
+	for i := 0; i < n; i++ {
+		M[i] = make([]*big.Rat, n)
+		for j := 0; j < n; j++ {
+			M[i][j] = new(big.Rat).Set(lcp.M(i, j))
+		}
+		q[i] = new(big.Rat).Set(lcp.q[i])
+	}
+
+	p := &Preprocessed{n: n}
+	active := make([]int, n)
+	for i := range active {
+		active[i] = i
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for k := 0; k < len(active); k++ {
+			i := active[k]
+			switch {
+			case q[i].Sign() > 0 && rowSigns(M[i], active, -1, -1):
+				p.ForcedZero++
+			case q[i].Sign() < 0 && rowSigns(M[i], active, i, 1):
+				if M[i][i].Sign() <= 0 {
+					return nil, fmt.Errorf("%w: w_%d < 0 for every z >= 0", ErrInfeasible, i+1)
+				}
+				p.pivots = append(p.pivots, eliminate(M, q, active, i))
+				p.ForcedBasic++
+			default:
+				continue
+			}
+			active = append(active[:k], active[k+1:]...)
+			k--
+			changed = true
+		}
+
+		for k := 0; k < len(active); k++ {
+			for l := len(active) - 1; l > k; l-- {
+				if duplicate(M, q, active, active[k], active[l]) {
+					active = append(active[:l], active[l+1:]...)
+					p.Duplicates++
+					changed = true
+				}
+			}
+		}
+	}
+
+	p.keep = active
+	if len(active) == 0 {
+		return p, nil
+	}
+
+	m := len(active)
+	reduced := make([]*big.Rat, m*m)
+	p.rowScale = make([]*big.Rat, m)
+	p.colScale = make([]*big.Rat, m)
+	rq := make([]*big.Rat, m)
+	for r, i := range active {
+		p.rowScale[r] = rowScale(M[i], q[i], active)
+		for c, j := range active {
+			reduced[r*m+c] = new(big.Rat).Mul(M[i][j], p.rowScale[r])
+		}
+		rq[r] = new(big.Rat).Mul(q[i], p.rowScale[r])
+	}
+	for c := range active {
+		gcd := new(big.Int)
+		for r := 0; r < m; r++ {
+			gcd.GCD(nil, nil, gcd, reduced[r*m+c].Num())
+		}
+		if gcd.Sign() == 0 {
+			gcd.SetInt64(1)
+		}
+		p.colScale[c] = new(big.Rat).SetFrac(big.NewInt(1), gcd)
+		for r := 0; r < m; r++ {
+			reduced[r*m+c].Mul(reduced[r*m+c], p.colScale[c])
+		}
+	}
+
+	var err error
+	p.LCP, err = NewLCP(reduced, rq)
+	return p, err
+}
+
+// rowSigns reports whether sign * M_ij <= 0 over the active j other
+// than skip.
+func rowSigns(row []*big.Rat, active []int, skip int, sign int) bool {
+	for _, j := range active {
+		if j != skip && row[j].Sign()*sign > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// eliminate makes z_i basic in row i by a principal pivot on M_ii and
+// substitutes it into the other active rows.
+func eliminate(M [][]*big.Rat, q []*big.Rat, active []int, i int) forcedPivot {
+
+	pivot := forcedPivot{
+		i:    i,
+		diag: new(big.Rat).Set(M[i][i]),
+		q:    new(big.Rat).Set(q[i]),
+		row:  make(map[int]*big.Rat),
+		col:  make(map[int]*big.Rat),
+	}
+	for _, j := range active {
+		if j != i && M[i][j].Sign() != 0 {
+			pivot.row[j] = new(big.Rat).Set(M[i][j])
+		}
+	}
+
+	factor, tmp := new(big.Rat), new(big.Rat)
+	for _, r := range active {
+		if r == i || M[r][i].Sign() == 0 {
+			continue
+		}
+		factor.Quo(M[r][i], pivot.diag)
+		pivot.col[r] = new(big.Rat).Set(factor)
+		for j, value := range pivot.row {
+			M[r][j].Sub(M[r][j], tmp.Mul(factor, value))
+		}
+		q[r].Sub(q[r], tmp.Mul(factor, pivot.q))
+	}
+	return pivot
+}
+
+// duplicate reports whether variables i and j have the same q entry and
+// the same rows and columns over the active indices.
+func duplicate(M [][]*big.Rat, q []*big.Rat, active []int, i int, j int) bool {
+	if q[i].Cmp(q[j]) != 0 {
+		return false
+	}
+	for _, k := range active {
+		if M[i][k].Cmp(M[j][k]) != 0 || M[k][i].Cmp(M[k][j]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// rowScale is the positive factor that makes the active entries of row
+// and q integers without a common divisor.
+func rowScale(row []*big.Rat, q *big.Rat, active []int) *big.Rat {
+
+	lcm := new(big.Int).Set(q.Denom())
+	for _, j := range active {
+		denom := row[j].Denom()
+		gcd := new(big.Int).GCD(nil, nil, lcm, denom)
+		lcm.Mul(lcm.Div(lcm, gcd), denom)
+	}
+
+	gcd := new(big.Int).Abs(new(big.Int).Div(new(big.Int).Mul(q.Num(), lcm), q.Denom()))
+	tmp := new(big.Int)
+	for _, j := range active {
+		tmp.Div(tmp.Mul(row[j].Num(), lcm), row[j].Denom())
+		gcd.GCD(nil, nil, gcd, tmp.Abs(tmp))
+	}
+	if gcd.Sign() == 0 {
+		gcd.SetInt64(1)
+	}
+	return new(big.Rat).SetFrac(lcm, gcd)
+}
+
+// CoveringVector maps a covering vector d of the original LCP to one
+// of the reduced LCP: d with the forced basic  z_i  eliminated like q,
+// i.e.  d_r - M_ri d_i / M_ii  for each of their pivots, then the
+// entries of the variables kept, scaled like their rows.  Lemke's
+// algorithm on the reduced LCP then follows the original one with z_i
+// basic.  The result may be negative somewhere, which Solve rejects.
+// A nil d stays nil.
+func (p *Preprocessed) CoveringVector(d []*big.Rat) ([]*big.Rat, error) {
+
+	if d == nil {
+		return nil, nil
+	}
+	if len(d) != p.n {
+		return nil, fmt.Errorf("%w: d has %d entries, the LCP has %d rows", ErrDimension, len(d), p.n)
+	}
+
+	eliminated, _ := p.covering(d)
+	reduced := make([]*big.Rat, len(p.keep))
+	for r, i := range p.keep {
+		reduced[r] = new(big.Rat).Mul(eliminated[i], p.rowScale[r])
+	}
+	return reduced, nil
+}
+
+// covering is d after the forced pivots, and the entry  d_i  of each
+// pivot row at the time of its pivot.
+func (p *Preprocessed) covering(d []*big.Rat) ([]*big.Rat, []*big.Rat) {
+
+	eliminated := make([]*big.Rat, p.n)
+	for i := range eliminated {
+		eliminated[i] = new(big.Rat).Set(d[i])
+	}
+	pivotD := make([]*big.Rat, len(p.pivots))
+	tmp := new(big.Rat)
+	for k, pivot := range p.pivots {
+		pivotD[k] = new(big.Rat).Set(eliminated[pivot.i])
+		for r, factor := range pivot.col {
+			eliminated[r].Sub(eliminated[r], tmp.Mul(factor, pivotD[k]))
+		}
+	}
+	return eliminated, pivotD
+}
+
+// Expand maps a solution z of the reduced LCP to a solution of the
+// original LCP.  With no variables left z is ignored.
+func (p *Preprocessed) Expand(z []*big.Rat) ([]*big.Rat, error) {
+
+	if len(p.keep) > 0 && len(z) != len(p.keep) {
+		return nil, fmt.Errorf("%w: z has %d entries, the reduced LCP has %d rows", ErrDimension, len(z), len(p.keep))
+	}
+	return p.expand(z, true, nil, nil), nil
+}
+
+// expand is Expand of z of the right size, without q in the forced
+// pivots if not  withQ, which maps a direction instead of a point, and
+// with their  d_i z0  if pivotD is not nil.
+func (p *Preprocessed) expand(z []*big.Rat, withQ bool, pivotD []*big.Rat, z0 *big.Rat) []*big.Rat {
+
+	full := make([]*big.Rat, p.n)
+	for i := range full {
+		full[i] = new(big.Rat)
+	}
+	for c, i := range p.keep {
+		full[i].Mul(z[c], p.colScale[c])
+	}
+
+	// each pivot only refers to variables still there when it was made,
+	// so back substitution in reverse order finds them all computed
+	tmp := new(big.Rat)
+	for k := len(p.pivots) - 1; k >= 0; k-- {
+		pivot := p.pivots[k]
+		value := full[pivot.i].SetInt64(0)
+		if withQ {
+			value.Set(pivot.q)
+		}
+		if pivotD != nil {
+			value.Add(value, tmp.Mul(pivotD[k], z0))
+		}
+		for j, coef := range pivot.row {
+			value.Add(value, tmp.Mul(coef, full[j]))
+		}
+		value.Quo(value.Neg(value), pivot.diag)
+	}
+	return full
+}
+
+// basis maps a basis of the reduced LCP to one of the original, row by
+// row: a removed variable has z basic if it was forced basic and w
+// otherwise.
+func (p *Preprocessed) basis(reduced []Variable) []Variable {
+
+	full := make([]Variable, p.n)
+	for i := range full {
+		full[i] = W(i + 1)
+	}
+	for _, pivot := range p.pivots {
+		full[pivot.i] = Z(pivot.i + 1)
+	}
+	for r, v := range reduced {
+		switch {
+		case v > 0:
+			v = Z(p.keep[v.Index()-1] + 1)
+		case v < 0:
+			v = W(p.keep[v.Index()-1] + 1)
+		}
+		full[p.keep[r]] = v
+	}
+	return full
+}
+
+/*
+ * result
+ * ================================================================
+ * maps the result of the reduced LCP, nil if nothing was left to
+ * solve or its q was nonnegative, to one of the original.  Z is
+ * mapped by Expand with the  d_i z0  of the forced pivots, for the d
+ * that CoveringVector eliminated, and W is  Mz + q + d z0, so the
+ * forced basic  w_i  stay zero whatever z0.  The Ray is mapped like Z
+ * without q, with its own z0.
+ */
+func (p *Preprocessed) result(lcp *LCP, d []*big.Rat, reduced *Result) *Result {
+
+	res := &Result{Status: Solved, Z0: new(big.Rat), Ties: []int{}}
+	if reduced != nil {
+		copied := *reduced
+		res = &copied
+	}
+
+	z := make([]*big.Rat, len(p.keep))
+	for c := range z {
+		z[c] = new(big.Rat)
+		if reduced != nil {
+			z[c] = reduced.Z[c]
+		}
+	}
+	var pivotD []*big.Rat
+	if d != nil {
+		_, pivotD = p.covering(d)
+	}
+	res.Z = p.expand(z, true, pivotD, res.Z0)
+
+	report, _ := Verify(lcp, res.Z)
+	res.W = report.Slack
+	if d != nil && res.Z0.Sign() != 0 {
+		for i := range res.W {
+			res.W[i].Add(res.W[i], new(big.Rat).Mul(d[i], res.Z0))
+		}
+	}
+
+	basis := allW(len(p.keep))
+	if reduced != nil {
+		basis = reduced.Basis
+	}
+	res.Basis = p.basis(basis)
+
+	if res.Ray != nil {
+		res.Ray = append([]*big.Rat{res.Ray[0]}, p.expand(res.Ray[1:], false, pivotD, res.Ray[0])...)
+	}
+	return res
+}
+
+// SolvePreprocessed preprocesses lcp, solves the reduced LCP as
+// configured by opts and maps its Result back to lcp, see Preprocessed.
+// Like Solve it returns ErrTrivialSolution if q >= 0.  The reduced LCP
+// may have q >= 0 without that, then its z = 0 is Solved without pivots.
+// d goes to the reduced LCP by CoveringVector, so it fails with
+// ErrBadCoveringVector where that is not a covering vector any more.
+func SolvePreprocessed(lcp *LCP, d []*big.Rat, opts *Options) (*Result, error) {
+	return SolvePreprocessedContext(context.Background(), lcp, d, opts)
+}
+
+// SolvePreprocessedContext is SolvePreprocessed but checks ctx before
+// every pivot.  Once ctx is done the mapped Result so far comes with a
+// *CanceledError.
+func SolvePreprocessedContext(ctx context.Context, lcp *LCP, d []*big.Rat, opts *Options) (*Result, error) {
+
+	if d != nil && len(d) != lcp.n {
+		return nil, fmt.Errorf("%w: d has %d entries, the LCP has %d rows", ErrDimension, len(d), lcp.n)
+	}
+
+	trivial := true
+	for _, qi := range lcp.q {
+		trivial = trivial && qi.Sign() >= 0
+	}
+	if trivial {
+		return nil, ErrTrivialSolution
+	}
+
+	p, err := Preprocess(lcp)
+	if err != nil {
+		return nil, err
+	}
+	if p.LCP == nil {
+		return p.result(lcp, d, nil), nil
+	}
+
+	reducedD, err := p.CoveringVector(d)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := SolveResultContext(ctx, p.LCP, reducedD, opts)
+	if errors.Is(err, ErrTrivialSolution) {
+		return p.result(lcp, d, nil), nil
+	}
+	if res == nil {
+		return nil, err
+	}
+
+	mapped := p.result(lcp, d, res)
+	var canceled *CanceledError
+	if errors.As(err, &canceled) {
+		err = &CanceledError{Result: mapped, Err: canceled.Err}
+	}
+	return mapped, err
+}
@@ -0,0 +1,238 @@
+package lemke
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertSolves(t *testing.T, lcp *LCP, z []*big.Rat) {
+	report, err := Verify(lcp, z)
+	assert.Nil(t, err)
+	assert.True(t, report.OK(), "%v", report.Violations)
+}
+
+func ratStrings(rats []*big.Rat) []string {
+	strs := make([]string, len(rats))
+	for i, rat := range rats {
+		strs[i] = rat.RatString()
+	}
+	return strs
+}
+
+func TestPreprocessForcedZero(t *testing.T) {
+
+	// w_1 = 2 + z_2 + 3z_3 > 0 so z_1 = 0
+	M := ints2rats([]int{
+		1, 1, 3,
+		-1, 2, 1,
+		2, 1, 3})
+	q := ints2rats([]int{2, -1, -2})
+	lcp := newTestLCP(t, M, q)
+
+	p, err := Preprocess(lcp)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, p.ForcedZero)
+	assert.Equal(t, 2, p.LCP.N())
+
+	res, err := SolvePreprocessed(lcp, ints2rats([]int{1, 1, 1}), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "0", res.Z[0].RatString())
+	assertSolves(t, lcp, res.Z)
+}
+
+func TestPreprocessForcedBasic(t *testing.T) {
+
+	// a Z-matrix: every row is forced basic in turn
+	M := ints2rats([]int{
+		2, -1, 0,
+		-1, 2, -1,
+		0, -1, 2})
+	q := ints2rats([]int{-1, -1, -1})
+	lcp := newTestLCP(t, M, q)
+
+	p, err := Preprocess(lcp)
+	assert.Nil(t, err)
+	assert.Nil(t, p.LCP)
+	assert.Equal(t, 3, p.ForcedBasic)
+
+	res, err := SolvePreprocessed(lcp, ints2rats([]int{1, 1, 1}), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3/2", "2", "3/2"}, ratStrings(res.Z))
+	assertSolves(t, lcp, res.Z)
+}
+
+func TestPreprocessDuplicates(t *testing.T) {
+
+	M := ints2rats([]int{
+		1, 1, 2,
+		1, 1, 2,
+		3, 3, 1})
+	q := ints2rats([]int{-1, -1, -2})
+	lcp := newTestLCP(t, M, q)
+
+	p, err := Preprocess(lcp)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, p.Duplicates)
+	assert.Equal(t, 2, p.LCP.N())
+
+	res, err := SolvePreprocessed(lcp, ints2rats([]int{1, 1, 1}), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "0", res.Z[1].RatString())
+	assertSolves(t, lcp, res.Z)
+}
+
+func TestPreprocessScaling(t *testing.T) {
+
+	M := []*big.Rat{
+		big.NewRat(1, 2), big.NewRat(1, 3),
+		big.NewRat(12, 1), big.NewRat(2, 1)}
+	q := []*big.Rat{big.NewRat(-1, 1), big.NewRat(-4, 1)}
+	lcp := newTestLCP(t, M, q)
+
+	p, err := Preprocess(lcp)
+	assert.Nil(t, err)
+
+	// rows (3 2 -6) and (6 1 -2), then column 1 divided by 3
+	assert.Equal(t, []string{"1", "2", "2", "1"}, []string{
+		p.LCP.M(0, 0).RatString(), p.LCP.M(0, 1).RatString(),
+		p.LCP.M(1, 0).RatString(), p.LCP.M(1, 1).RatString()})
+	assert.Equal(t, []string{"-6", "-2"}, []string{p.LCP.Q(0).RatString(), p.LCP.Q(1).RatString()})
+
+	d, err := p.CoveringVector(ints2rats([]int{1, 1}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"6", "1/2"}, ratStrings(d))
+
+	res, err := SolvePreprocessed(lcp, ints2rats([]int{1, 1}), nil)
+	assert.Nil(t, err)
+	assertSolves(t, lcp, res.Z)
+}
+
+func TestPreprocessMatchesSolve(t *testing.T) {
+
+	for seed := int64(1); seed <= 10; seed++ {
+		lcp, d := randomTestLCP(t, 8, seed)
+
+		expected, err := Solve(lcp, d)
+		assert.Nil(t, err)
+
+		// a P-matrix LCP has one solution
+		actual, err := SolvePreprocessed(lcp, d, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, Solved, actual.Status)
+		assert.Equal(t, solutionKey(expected), solutionKey(actual.Z))
+		assertMapped(t, lcp, actual)
+	}
+
+	for seed := int64(1); seed <= 10; seed++ {
+		lcp, d := bimatrixTestLCP(t, 4, 100, seed)
+
+		res, err := SolvePreprocessed(lcp, d, nil)
+		assert.Nil(t, err)
+		assertSolves(t, lcp, res.Z)
+		assertMapped(t, lcp, res)
+	}
+}
+
+func TestPreprocessErrors(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{-1, 0, 1, 1}), ints2rats([]int{-1, -1}))
+	_, err := Preprocess(lcp)
+	assert.True(t, errors.Is(err, ErrInfeasible))
+
+	trivial := newTestLCP(t, ints2rats([]int{1, -1, -1, 1}), ints2rats([]int{0, 1}))
+	_, err = SolvePreprocessed(trivial, ints2rats([]int{1, 1}), nil)
+	assert.Equal(t, ErrTrivialSolution, err)
+
+	_, err = SolvePreprocessed(trivial, ints2rats([]int{1}), nil)
+	assert.True(t, errors.Is(err, ErrDimension))
+
+	p, err := Preprocess(trivial)
+	assert.Nil(t, err)
+	_, err = p.Expand(ints2rats([]int{1, 2, 3}))
+	assert.True(t, errors.Is(err, ErrDimension))
+}
+
+// assertMapped checks that the basis of res is complementary and that
+// only its variables are nonzero.
+func assertMapped(t *testing.T, lcp *LCP, res *Result) {
+
+	report, err := Verify(lcp, res.Z)
+	assert.Nil(t, err)
+	assert.Equal(t, ratStrings(report.Slack), ratStrings(res.W))
+
+	basic := make(map[Variable]bool)
+	for _, v := range res.Basis {
+		basic[v] = true
+	}
+	assert.Equal(t, lcp.N(), len(basic))
+	for i := 0; i < lcp.N(); i++ {
+		assert.True(t, basic[Z(i+1)] != basic[W(i+1)], "pair %d", i+1)
+		if !basic[Z(i+1)] {
+			assert.Equal(t, 0, res.Z[i].Sign(), "z%d", i+1)
+		}
+		if !basic[W(i+1)] {
+			assert.Equal(t, 0, res.W[i].Sign(), "w%d", i+1)
+		}
+	}
+}
+
+func TestPreprocessedResult(t *testing.T) {
+
+	// w_3 > 0 forces z_3 = 0, and the rest has no solution
+	M := ints2rats([]int{
+		-1, 1, 0,
+		1, -1, 0,
+		1, 1, 1})
+	q := ints2rats([]int{-1, -1, 1})
+	lcp := newTestLCP(t, M, q)
+
+	res, err := SolvePreprocessed(lcp, ints2rats([]int{1, 1, 1}), nil)
+	assert.True(t, errors.Is(err, ErrRayTermination))
+	assert.Equal(t, RayTermination, res.Status)
+	assert.Equal(t, W(3), res.Basis[2])
+	assert.Equal(t, 4, len(res.Ray))
+	assert.Equal(t, "0", res.Ray[3].RatString())
+
+	// z_3 is forced basic, so d_1 loses  M_13 d_3 / M_33  and the ray
+	// and slacks with z0 > 0 are those of the original LCP
+	M = ints2rats([]int{
+		-1, 1, 1,
+		1, -1, 0,
+		-1, -1, 1})
+	q = ints2rats([]int{-1, -1, -1})
+	lcp = newTestLCP(t, M, q)
+	d := ints2rats([]int{1, 1, 1})
+
+	p, err := Preprocess(lcp)
+	assert.Nil(t, err)
+	reducedD, err := p.CoveringVector(d)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0", "1"}, ratStrings(reducedD))
+
+	expected, err := SolveResult(lcp, d, nil)
+	assert.True(t, errors.Is(err, ErrRayTermination))
+	res, err = SolvePreprocessed(lcp, d, nil)
+	assert.True(t, errors.Is(err, ErrRayTermination))
+	assert.Equal(t, ratStrings(expected.Ray), ratStrings(res.Ray))
+	assert.Equal(t, ratStrings(expected.Z), ratStrings(res.Z))
+	assert.Equal(t, expected.Z0.RatString(), res.Z0.RatString())
+	assert.Equal(t, ratStrings(expected.W), ratStrings(res.W))
+
+	// z_2 = 2 is forced, then  w_1 = z_1 + 1 > 0
+	M = ints2rats([]int{
+		1, 0,
+		-1, 1})
+	q = ints2rats([]int{1, -2})
+	lcp = newTestLCP(t, M, q)
+
+	res, err = SolvePreprocessed(lcp, ints2rats([]int{1, 1}), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, res.Status)
+	assert.Equal(t, 0, res.Pivots)
+	assert.Equal(t, []string{"0", "2"}, ratStrings(res.Z))
+	assert.Equal(t, []Variable{W(1), Z(2)}, res.Basis)
+	assertMapped(t, lcp, res)
+}
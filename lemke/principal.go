@@ -0,0 +1,205 @@
+package lemke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Method is the algorithm used to solve an LCP.
+type Method int
+
+const (
+	// Lemke is Lemke's algorithm with covering vector d, the default.
+	Lemke Method = iota
+	// PrincipalPivoting is the principal pivoting method of Dantzig and
+	// Cottle.  It needs no covering vector and is guaranteed to work if M
+	// is a P-matrix or positive semidefinite.
+	PrincipalPivoting
+)
+
+func (m Method) String() string {
+	switch m {
+	case Lemke:
+		return "Lemke"
+	case PrincipalPivoting:
+		return "principal pivoting"
+	}
+	return "unknown"
+}
+
+/*
+ * principalPivoting
+ * ================================================================
+ * Dantzig-Cottle principal pivoting on the tableau of  w = q + Mz
+ * without z0.  Every major cycle starts from a complementary basis
+ * and picks a negative basic variable, the distinguished one.  Its
+ * complement, the driver, is increased until either the distinguished
+ * variable reaches zero, then it leaves and the basis is complementary
+ * again, or some other nonnegative basic variable would go negative,
+ * then that one leaves and its complement becomes the driver.  Basic
+ * variables that are nonnegative stay so, and every major cycle makes
+ * one more of them nonnegative.
+ */
+func principalPivoting(ctx context.Context, lcp *LCP, opts *Options) (*Result, error) {
+
+	d := make([]*big.Rat, lcp.n)
+	for i := range d {
+		d[i] = new(big.Rat) // no z0 here
+	}
+
+	tableau, scaleFactors := fillTableau(lcp, d, opts.Storage)
+	tableau.workers = opts.Workers
+	tableau.negateCol(tableau.rhsCol())
+
+	rule, _ := opts.pivotRule(lcp.n)
+
+	status := Solved
+	pivotCount := 0
+	tieCounts := make([]int, 0)
+
+major:
+	for {
+		distinguished := negativeBasic(tableau)
+		if distinguished == nil {
+			break
+		}
+
+		driver, err := distinguished.complement()
+		if err != nil {
+			return nil, err
+		}
+
+		for {
+
+			if ctx.Err() != nil {
+				res := newResult(tableau, scaleFactors, Canceled, pivotCount)
+				res.Ties = tieCounts
+				return res, &CanceledError{Result: res, Err: ctx.Err()}
+			}
+
+			if pivotCount == opts.MaxPivots && pivotCount > 0 {
+				status = PivotLimit
+				break major
+			}
+
+			leave, ties, err := blockingVariable(tableau, rule, distinguished, driver)
+			if errors.Is(err, ErrRayTermination) {
+				res := newResult(tableau, scaleFactors, RayTermination, pivotCount)
+				res.Ties = tieCounts
+				res.Ray = ray(tableau, scaleFactors, driver)
+				return res, err
+			} else if err != nil {
+				return nil, err
+			}
+
+			row, col, err := tableau.pivot(leave, driver)
+			if err != nil {
+				return nil, err
+			}
+			pivotCount++
+			tieCounts = append(tieCounts, ties)
+			opts.notify(scaledTableau{tableau, scaleFactors}, pivotCount, driver, leave, row, col, ties)
+
+			if leave == distinguished {
+				break
+			}
+
+			driver, err = leave.complement()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	res := newResult(tableau, scaleFactors, status, pivotCount)
+	res.Ties = tieCounts
+	return res, nil
+}
+
+/*
+ * sign of the value of the basic variable of  row
+ * and of the rate at which it decreases as  col  increases
+ */
+func valueSign(A *tableau, row int) int {
+	return A.sign(row, A.rhsCol()) * A.det.Sign()
+}
+
+func decreaseSign(A *tableau, row int, col int) int {
+	return A.sign(row, col) * A.det.Sign()
+}
+
+// negativeBasic is the basic variable of least subscript, z before w,
+// that is negative, or nil if there is none.
+func negativeBasic(A *tableau) *tableauVariable {
+	var found *tableauVariable
+	for i := 0; i < A.nrows; i++ {
+		if valueSign(A, i) < 0 {
+			basic := A.vars.fromRow(i)
+			if found == nil || less(basic.variable(), found.variable()) {
+				found = basic
+			}
+		}
+	}
+	return found
+}
+
+/*
+ * blockingVariable
+ * ================================================================
+ * minimum ratio test for increasing  driver: the distinguished
+ * variable blocks where it reaches zero, every other nonnegative
+ * basic variable where it does.  The distinguished one wins ties,
+ * other ties are broken by  rule.
+ * @return also the number of rows tied with the blocking one
+ */
+func blockingVariable(A *tableau, rule PivotRule, distinguished *tableauVariable, driver *tableauVariable) (*tableauVariable, int, error) {
+
+	col := driver.col()
+	r := distinguished.row()
+
+	if decreaseSign(A, r, col) > 0 {
+		return nil, 0, fmt.Errorf("%w: %v decreases as %v increases, M is neither P nor positive semidefinite", ErrMatrixClass, distinguished, driver)
+	}
+
+	candidates := make([]int, 0, A.nrows)
+	for i := 0; i < A.nrows; i++ {
+		if i != r && valueSign(A, i) >= 0 && decreaseSign(A, i, col) > 0 {
+			candidates = append(candidates, i)
+		}
+	}
+
+	if len(candidates) > 0 {
+		candidates = minRatioTest(A, col, A.rhsCol(), candidates)
+	}
+
+	if decreaseSign(A, r, col) < 0 {
+		if len(candidates) == 0 {
+			return distinguished, 0, nil
+		}
+		if sgn := compareRatios(A, r, candidates[0], col); sgn < 0 {
+			return distinguished, 0, nil
+		} else if sgn == 0 {
+			return distinguished, len(candidates), nil
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, 0, fmt.Errorf("%w when trying to enter %s, the LCP is infeasible", ErrRayTermination, driver)
+	}
+
+	ties := len(candidates) - 1
+	row := candidates[0]
+	if ties > 0 {
+		row = rule.BreakTie(ratioView{A}, col, candidates)
+	}
+	return A.vars.fromRow(row), ties, nil
+}
+
+// compareRatios is the sign of  rhs_a / A[a][col] - rhs_b / A[b][col]
+// whatever the signs of the two entries of col.
+func compareRatios(A *tableau, a int, b int, col int) int {
+	sgn := A.ratioTest(a, b, col, A.rhsCol())
+	return sgn * A.sign(a, col) * A.sign(b, col)
+}
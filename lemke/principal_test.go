@@ -0,0 +1,83 @@
+package lemke
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ppmOptions = &Options{Method: PrincipalPivoting}
+
+func TestPrincipalPivotingPMatrix(t *testing.T) {
+
+	M := ints2rats([]int{2, 1, 1, 3})
+	q := ints2rats([]int{-1, -1})
+
+	res, err := SolveResult(newTestLCP(t, M, q), nil, ppmOptions)
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, res.Status)
+	assert.Equal(t, "2/5", res.Z[0].RatString())
+	assert.Equal(t, "1/5", res.Z[1].RatString())
+	assert.Equal(t, 0, res.W[0].Sign())
+	assert.Equal(t, 0, res.W[1].Sign())
+	assert.Equal(t, len(res.Ties), res.Pivots)
+}
+
+func TestPrincipalPivotingPSD(t *testing.T) {
+
+	M := ints2rats([]int{1, -1, -1, 1})
+	q := ints2rats([]int{-1, 1})
+
+	res, err := SolveResult(newTestLCP(t, M, q), nil, ppmOptions)
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, res.Status)
+	assert.Equal(t, "1", res.Z[0].RatString())
+	assert.Equal(t, "0", res.Z[1].RatString())
+}
+
+func TestPrincipalPivotingTrivial(t *testing.T) {
+
+	M := ints2rats([]int{1, 0, 0, 1})
+	q := ints2rats([]int{0, 2})
+
+	res, err := SolveResult(newTestLCP(t, M, q), nil, ppmOptions)
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, res.Status)
+	assert.Equal(t, 0, res.Pivots)
+	assert.Equal(t, "2", res.W[1].RatString())
+}
+
+func TestPrincipalPivotingInfeasible(t *testing.T) {
+
+	M := ints2rats([]int{0, 1, -1, 0})
+	q := ints2rats([]int{-1, -1})
+
+	res, err := SolveResult(newTestLCP(t, M, q), nil, ppmOptions)
+	assert.True(t, errors.Is(err, ErrRayTermination))
+	assert.Equal(t, RayTermination, res.Status)
+	assert.Equal(t, 1, res.Ray[1].Sign())
+}
+
+func TestPrincipalPivotingWrongClass(t *testing.T) {
+
+	M := ints2rats([]int{-1})
+	q := ints2rats([]int{-1})
+
+	_, err := SolveResult(newTestLCP(t, M, q), nil, ppmOptions)
+	assert.True(t, errors.Is(err, ErrMatrixClass))
+}
+
+func TestPrincipalPivotingMatchesLemke(t *testing.T) {
+
+	for seed := int64(1); seed <= 10; seed++ {
+		lcp, d := randomTestLCP(t, 8, seed)
+
+		expected, err := Solve(lcp, d)
+		assert.Nil(t, err)
+
+		res, err := SolveResult(lcp, nil, ppmOptions)
+		assert.Nil(t, err)
+		assert.Equal(t, solutionKey(expected), solutionKey(res.Z))
+	}
+}
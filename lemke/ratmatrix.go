@@ -0,0 +1,259 @@
+package lemke
+
+import "math/big"
+
+// ratMatrix is a small dense matrix of rationals for exact linear algebra
+// outside the tableau, e.g. on principal submatrices of M.
+type ratMatrix [][]*big.Rat
+
+func newRatMatrix(nrows int, ncols int) ratMatrix {
+	A := make(ratMatrix, nrows)
+	for i := range A {
+		A[i] = make([]*big.Rat, ncols)
+		for j := range A[i] {
+			A[i][j] = new(big.Rat)
+		}
+	}
+	return A
+}
+
+// matrix is M as a ratMatrix.
+func (lcp *LCP) matrix() ratMatrix {
+	A := newRatMatrix(lcp.n, lcp.n)
+	for i := 0; i < lcp.n; i++ {
+		for j := 0; j < lcp.n; j++ {
+			A[i][j].Set(lcp.M(i, j))
+		}
+	}
+	return A
+}
+
+// symmetricPart is  (A + A^T) / 2, it has the same quadratic form as A.
+func (A ratMatrix) symmetricPart() ratMatrix {
+	n := len(A)
+	S := newRatMatrix(n, n)
+	half := big.NewRat(1, 2)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			S[i][j].Add(A[i][j], A[j][i])
+			S[i][j].Mul(S[i][j], half)
+		}
+	}
+	return S
+}
+
+// sub is the submatrix of the given rows and cols, sharing no entries.
+func (A ratMatrix) sub(rows []int, cols []int) ratMatrix {
+	B := newRatMatrix(len(rows), len(cols))
+	for i, row := range rows {
+		for j, col := range cols {
+			B[i][j].Set(A[row][col])
+		}
+	}
+	return B
+}
+
+func (A ratMatrix) clone() ratMatrix {
+	B := make(ratMatrix, len(A))
+	for i := range A {
+		B[i] = make([]*big.Rat, len(A[i]))
+		for j := range A[i] {
+			B[i][j] = new(big.Rat).Set(A[i][j])
+		}
+	}
+	return B
+}
+
+func (A ratMatrix) mulVec(x []*big.Rat) []*big.Rat {
+	y := make([]*big.Rat, len(A))
+	tmp := new(big.Rat)
+	for i := range A {
+		y[i] = new(big.Rat)
+		for j := range x {
+			y[i].Add(y[i], tmp.Mul(A[i][j], x[j]))
+		}
+	}
+	return y
+}
+
+// quadForm is  x^T A x
+func (A ratMatrix) quadForm(x []*big.Rat) *big.Rat {
+	return dot(x, A.mulVec(x))
+}
+
+func dot(x []*big.Rat, y []*big.Rat) *big.Rat {
+	sum := new(big.Rat)
+	tmp := new(big.Rat)
+	for i := range x {
+		sum.Add(sum, tmp.Mul(x[i], y[i]))
+	}
+	return sum
+}
+
+/*
+ * rref
+ * ================================================================
+ * reduced row echelon form of  A, which is changed in place
+ * @return the pivot column of each nonzero row
+ */
+func (A ratMatrix) rref() []int {
+
+	var pivotCols []int
+	if len(A) == 0 {
+		return pivotCols
+	}
+
+	row := 0
+	tmp := new(big.Rat)
+	for col := 0; col < len(A[0]) && row < len(A); col++ {
+
+		pivot := -1
+		for i := row; i < len(A); i++ {
+			if A[i][col].Sign() != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		A[row], A[pivot] = A[pivot], A[row]
+
+		inv := new(big.Rat).Inv(A[row][col])
+		for j := col; j < len(A[row]); j++ {
+			A[row][j].Mul(A[row][j], inv)
+		}
+
+		for i := range A {
+			if i == row || A[i][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(A[i][col])
+			for j := col; j < len(A[i]); j++ {
+				A[i][j].Sub(A[i][j], tmp.Mul(factor, A[row][j]))
+			}
+		}
+
+		pivotCols = append(pivotCols, col)
+		row++
+	}
+	return pivotCols
+}
+
+// det is the determinant of the square matrix A.
+func (A ratMatrix) det() *big.Rat {
+
+	B := A.clone()
+	n := len(B)
+	det := big.NewRat(1, 1)
+	tmp := new(big.Rat)
+	for col := 0; col < n; col++ {
+
+		pivot := -1
+		for i := col; i < n; i++ {
+			if B[i][col].Sign() != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			return new(big.Rat)
+		}
+		if pivot != col {
+			B[col], B[pivot] = B[pivot], B[col]
+			det.Neg(det)
+		}
+		det.Mul(det, B[col][col])
+
+		for i := col + 1; i < n; i++ {
+			if B[i][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Quo(B[i][col], B[col][col])
+			for j := col; j < n; j++ {
+				B[i][j].Sub(B[i][j], tmp.Mul(factor, B[col][j]))
+			}
+		}
+	}
+	return det
+}
+
+// inverse of the square matrix A, false if it is singular.
+func (A ratMatrix) inverse() (ratMatrix, bool) {
+
+	n := len(A)
+	B := newRatMatrix(n, 2*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			B[i][j].Set(A[i][j])
+		}
+		B[i][n+i].SetInt64(1)
+	}
+
+	if pivotCols := B.rref(); len(pivotCols) < n || pivotCols[n-1] >= n {
+		return nil, false
+	}
+
+	inv := make(ratMatrix, n)
+	for i := 0; i < n; i++ {
+		inv[i] = B[i][n:]
+	}
+	return inv, true
+}
+
+// solve  Ax = b  for square A, false unless the solution is unique.
+func (A ratMatrix) solve(b []*big.Rat) ([]*big.Rat, bool) {
+
+	n := len(A)
+	B := newRatMatrix(n, n+1)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			B[i][j].Set(A[i][j])
+		}
+		B[i][n].Set(b[i])
+	}
+
+	if pivotCols := B.rref(); len(pivotCols) < n || pivotCols[n-1] >= n {
+		return nil, false
+	}
+
+	x := make([]*big.Rat, n)
+	for i := 0; i < n; i++ {
+		x[i] = B[i][n]
+	}
+	return x, true
+}
+
+// nullSpace is a basis of  { x | Ax = 0 }.
+func (A ratMatrix) nullSpace() [][]*big.Rat {
+
+	if len(A) == 0 {
+		return nil
+	}
+
+	B := A.clone()
+	ncols := len(B[0])
+	pivotCols := B.rref()
+
+	isPivot := make([]bool, ncols)
+	for _, col := range pivotCols {
+		isPivot[col] = true
+	}
+
+	var basis [][]*big.Rat
+	for free := 0; free < ncols; free++ {
+		if isPivot[free] {
+			continue
+		}
+		x := make([]*big.Rat, ncols)
+		for j := range x {
+			x[j] = new(big.Rat)
+		}
+		x[free].SetInt64(1)
+		for i, col := range pivotCols {
+			x[col].Neg(B[i][free])
+		}
+		basis = append(basis, x)
+	}
+	return basis
+}
@@ -0,0 +1,54 @@
+package lemke
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+)
+
+// restartCoveringMax bounds the entries of the random covering vectors
+// of a restart.
+const restartCoveringMax = 100
+
+// withRestarts runs  solve  on  d  and then, as long as it ends on a ray
+// or at the pivot limit, on up to opts.Restarts new covering vectors.
+func withRestarts(n int, d []*big.Rat, opts *Options, solve func(d []*big.Rat, opts *Options) (*Result, error)) (*Result, error) {
+
+	res, err := solve(d, opts)
+	attempts := 1
+
+	if opts.Restarts > 0 {
+		r := rand.New(rand.NewSource(opts.Seed))
+		cold := *opts
+		cold.WarmStart = nil
+		for ; attempts <= opts.Restarts && restartable(res, err); attempts++ {
+			res, err = solve(cold.coveringVector(r, n), &cold)
+		}
+	}
+
+	if res != nil {
+		res.Attempts = attempts
+	}
+	return res, err
+}
+
+// restartable is whether another covering vector may do better.
+func restartable(res *Result, err error) bool {
+	if err != nil {
+		return errors.Is(err, ErrRayTermination)
+	}
+	return res.Status == PivotLimit
+}
+
+func (opts *Options) coveringVector(r *rand.Rand, n int) []*big.Rat {
+
+	if opts.CoveringVector != nil {
+		return opts.CoveringVector(r)
+	}
+
+	d := make([]*big.Rat, n)
+	for i := range d {
+		d[i] = big.NewRat(int64(r.Intn(restartCoveringMax)+1), 1)
+	}
+	return d
+}
@@ -0,0 +1,69 @@
+package lemke
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestartsGiveUpOnRays(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{-1}), ints2rats([]int{-1}))
+	res, err := SolveResult(lcp, ints2rats([]int{1}), &Options{Restarts: 3})
+	assert.True(t, errors.Is(err, ErrRayTermination))
+	assert.Equal(t, 4, res.Attempts)
+}
+
+func TestRestartsAtPivotLimit(t *testing.T) {
+
+	lcp, _ := bimatrixTestLCP(t, 4, 10, 3)
+
+	// a covering vector that takes longer than another
+	ds := randomCoveringVectors(lcp.n, 20, 1)
+	pivots := make([]int, len(ds))
+	slow, fast := 0, 0
+	for k, d := range ds {
+		res, err := SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+		pivots[k] = res.Pivots
+		if pivots[k] > pivots[slow] {
+			slow = k
+		}
+		if pivots[k] < pivots[fast] {
+			fast = k
+		}
+	}
+	limit := pivots[fast]
+	assert.True(t, limit < pivots[slow])
+
+	res, err := SolveResult(lcp, ds[slow], &Options{MaxPivots: limit})
+	assert.Nil(t, err)
+	assert.Equal(t, PivotLimit, res.Status)
+	assert.Equal(t, 1, res.Attempts)
+
+	var tried int
+	res, err = SolveResult(lcp, ds[slow], &Options{MaxPivots: limit, Restarts: 5, CoveringVector: func(r *rand.Rand) []*big.Rat {
+		tried++
+		return ds[fast]
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, res.Status)
+	assert.Equal(t, 2, res.Attempts)
+	assert.Equal(t, 1, tried)
+}
+
+func TestRestartsAreSeeded(t *testing.T) {
+
+	lcp, d := randomTestLCP(t, 10, 5)
+	opts := &Options{MaxPivots: 3, Restarts: 10, Seed: 42}
+
+	first, err := SolveResult(lcp, d, opts)
+	assert.Nil(t, err)
+	again, err := SolveResult(lcp, d, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, first.Attempts, again.Attempts)
+	assert.Equal(t, solutionKey(first.Z), solutionKey(again.Z))
+}
@@ -0,0 +1,147 @@
+package lemke
+
+import "math/big"
+
+// Status tells how a Lemke run ended.
+type Status int
+
+const (
+	// Solved means z0 left the basis and Z is a complementary solution.
+	Solved Status = iota
+	// RayTermination means no variable could leave the basis for the
+	// entering one.  Result.Ray holds the unbounded direction.
+	RayTermination
+	// PivotLimit means Options.MaxPivots was reached first.
+	PivotLimit
+	// Canceled means the context of the run was done first.
+	Canceled
+	// Running means a Solver has not finished yet.
+	Running
+)
+
+func (s Status) String() string {
+	switch s {
+	case Solved:
+		return "solved"
+	case RayTermination:
+		return "ray termination"
+	case PivotLimit:
+		return "pivot limit reached"
+	case Canceled:
+		return "canceled"
+	case Running:
+		return "running"
+	}
+	return "unknown"
+}
+
+// Result is everything known about the final basis of a Lemke run.
+type Result struct {
+	Status Status
+
+	Z  []*big.Rat // z1..zn
+	W  []*big.Rat // w1..wn, equal to Mz + q once Solved
+	Z0 *big.Rat   // zero once Solved
+
+	// Basis is the basic variable of each tableau row.
+	Basis []Variable
+
+	// Pivots is the number of pivots performed, the first z0 pivot included.
+	Pivots int
+
+	// FactorPivots is how many of Pivots were made to reach
+	// Options.WarmStart before Lemke's algorithm proper.
+	FactorPivots int
+
+	// Ray is only set on RayTermination.  It is the direction (z0, z1..zn)
+	// along which the last almost complementary basis stays feasible.
+	Ray []*big.Rat
+
+	// Ties has an entry for every exact pivot: the number of other rows
+	// that had the same minimum ratio as the leaving one and were passed
+	// over by Options.PivotRule.  Pivots with ties are degenerate.  It is
+	// nil if no exact Lemke pivots were made, e.g. after FloatPivoting.
+	Ties []int
+
+	// FillIn is the number of zero entries of the tableau made nonzero by
+	// pivoting, only counted with SparseStorage.
+	FillIn int
+
+	// FloatFallback is set if Options.FloatPivoting was asked for but its
+	// basis did not survive exact verification.
+	FloatFallback bool
+
+	// Attempts is the number of covering vectors tried, see
+	// Options.Restarts.  It is zero for PrincipalPivoting.
+	Attempts int
+}
+
+func newResult(tableau *tableau, scaleFactors []*big.Int, status Status, pivots int) *Result {
+
+	n := tableau.vars.n
+	den := new(big.Int).Mul(tableau.det, scaleFactors[tableau.rhsCol()])
+
+	res := &Result{
+		Status: status,
+		Z:      solution(tableau, scaleFactors),
+		W:      make([]*big.Rat, n),
+		Z0:     result(tableau.vars.z(0), den, tableau, scaleFactors),
+		Basis:  make([]Variable, n),
+		Pivots: pivots,
+		FillIn: tableau.store.fillIn(),
+	}
+
+	for i := 0; i < n; i++ {
+		res.W[i] = result(tableau.vars.w(i+1), den, tableau, scaleFactors)
+		res.Basis[i] = tableau.vars.fromRow(i).variable()
+	}
+	return res
+}
+
+/*
+ * direction of the ray when  enter  cannot be blocked:
+ * enter grows by  scfa[enter] * det  and basic var of row  i
+ * by  -scfa[basic] * A[i][col]  (w vars have no scale factor),
+ * all negated if no pivot has made det positive yet
+ */
+func ray(tableau *tableau, scaleFactors []*big.Int, enter *tableauVariable) []*big.Rat {
+
+	n := tableau.vars.n
+	dir := make([]*big.Rat, n+1)
+	for i := 0; i <= n; i++ {
+		dir[i] = new(big.Rat)
+	}
+
+	if enter.isZ() {
+		num := new(big.Int).Mul(scaleFactors[enter.idx], tableau.det)
+		dir[enter.idx].SetInt(num)
+	}
+
+	col := enter.col()
+	for i := 0; i < n; i++ {
+		basic := tableau.vars.fromRow(i)
+		if basic.isZ() {
+			num := new(big.Int).Mul(scaleFactors[basic.idx], tableau.entry(i, col))
+			dir[basic.idx].SetInt(num.Neg(num))
+		}
+	}
+
+	if tableau.det.Sign() < 0 {
+		for i := 0; i <= n; i++ {
+			dir[i].Neg(dir[i])
+		}
+	}
+	return dir
+}
+
+// DegeneratePivots is the number of pivots at which the pivot rule had
+// to break a tie.
+func (res *Result) DegeneratePivots() int {
+	count := 0
+	for _, ties := range res.Ties {
+		if ties > 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,60 @@
+package lemke
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultSolved(t *testing.T) {
+
+	M := ints2rats([]int{2, 1, 1, 3})
+	q := ints2rats([]int{-1, -1})
+	d := ints2rats([]int{2, 1})
+
+	res, err := SolveResult(newTestLCP(t, M, q), d, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, res.Status)
+	assert.Equal(t, 3, res.Pivots)
+	assert.Equal(t, "2/5", res.Z[0].RatString())
+	assert.Equal(t, "1/5", res.Z[1].RatString())
+	assert.Equal(t, 0, res.W[0].Sign())
+	assert.Equal(t, 0, res.W[1].Sign())
+	assert.Equal(t, 0, res.Z0.Sign())
+	assert.ElementsMatch(t, []Variable{Z(1), Z(2)}, res.Basis)
+	assert.Nil(t, res.Ray)
+}
+
+func TestResultPivotLimit(t *testing.T) {
+
+	M := ints2rats([]int{2, 1, 1, 3})
+	q := ints2rats([]int{-1, -1})
+	d := ints2rats([]int{2, 1})
+
+	res, err := SolveResult(newTestLCP(t, M, q), d, &Options{MaxPivots: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, PivotLimit, res.Status)
+	assert.Equal(t, 1, res.Pivots)
+	assert.Equal(t, "1", res.Z0.RatString())
+	assert.Equal(t, "1", res.W[0].RatString())
+	assert.Equal(t, 0, res.W[1].Sign())
+}
+
+func TestResultRayTermination(t *testing.T) {
+
+	M := ints2rats([]int{-1})
+	q := ints2rats([]int{-1})
+	d := ints2rats([]int{1})
+
+	res, err := SolveResult(newTestLCP(t, M, q), d, nil)
+	assert.True(t, errors.Is(err, ErrRayTermination))
+	assert.Equal(t, RayTermination, res.Status)
+	assert.Equal(t, 2, len(res.Ray))
+	assert.Equal(t, 1, res.Ray[0].Sign())
+	assert.Equal(t, 0, res.Ray[0].Cmp(res.Ray[1]))
+
+	// z0 = 1 + z1 stays feasible along the ray
+	assert.Equal(t, 0, res.Z0.Cmp(big.NewRat(1, 1)))
+}
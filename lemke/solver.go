@@ -0,0 +1,235 @@
+package lemke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Solver runs Lemke's algorithm one pivot at a time, e.g. to show it
+// at work.  Solve and its variants are a Solver run to the end.
+//
+//	s, err := lemke.NewSolver(lcp, d, nil)
+//	for err == nil && !s.Done() {
+//		err = s.Step()
+//		fmt.Println(s.Tableau())
+//	}
+//	res := s.Result()
+type Solver struct {
+	tableau lemkeTableau
+	opts    *Options
+	rule    PivotRule
+
+	// next pivot: enter replaces leave, ties rows were tied with it
+	enter   *tableauVariable
+	leave   *tableauVariable
+	z0leave bool
+	ties    int
+
+	pivots       int
+	factorPivots int // of pivots, those to reach Options.WarmStart
+	tieCounts    []int
+	status       Status
+	err          error
+
+	solved *Result // a warm start that needed no pivots
+}
+
+// NewSolver sets up Lemke's algorithm for the LCP with covering vector
+// d as configured by opts, which may be nil, and makes the ratio test for
+// the first pivot.  Options.Observer is told about every Step.  A Solver
+// only pivots exactly, so FloatPivoting is ignored, and the
+// PrincipalPivoting method is not supported.
+func NewSolver(lcp *LCP, d []*big.Rat, opts *Options) (*Solver, error) {
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	if _, err := opts.pivotRule(lcp.n); err != nil {
+		return nil, err
+	}
+
+	if opts.Method != Lemke {
+		return nil, fmt.Errorf("%w: a Solver only steps through Lemke's method, not %v", ErrUnsupported, opts.Method)
+	}
+
+	if err := opts.checkBlock(); err != nil {
+		return nil, err
+	}
+	if opts.BlockSize > 0 {
+		return startBlock(lcp, d, opts)
+	}
+
+	tableau, scaleFactors, err := createTableau(lcp, d, opts.Storage)
+	if err != nil {
+		return nil, err
+	}
+	return startTableau(tableau, scaleFactors, d, opts)
+}
+
+// startTableau starts Lemke on a tableau freshly filled for  d, warm if
+// asked.
+func startTableau(tableau *tableau, scaleFactors []*big.Int, d []*big.Rat, opts *Options) (*Solver, error) {
+
+	tableau.workers = opts.Workers
+	if opts.WarmStart != nil {
+		return startWarm(tableau, scaleFactors, d, opts)
+	}
+	return startLemke(tableau, scaleFactors, opts)
+}
+
+// newSolver is about to pivot  enter  in for  leave, as found by the
+// first ratio test.
+func newSolver(tableau lemkeTableau, opts *Options, enter *tableauVariable, leave *tableauVariable, z0leave bool, ties int) *Solver {
+	rule, _ := opts.pivotRule(tableau.variables().n)
+	return &Solver{
+		tableau:   tableau,
+		opts:      opts,
+		rule:      rule,
+		enter:     enter,
+		leave:     leave,
+		z0leave:   z0leave,
+		ties:      ties,
+		tieCounts: make([]int, 0),
+		status:    Running,
+	}
+}
+
+// solvedSolver is done before its first step, the  pivots  made to
+// reach the warm start basis aside.
+func solvedSolver(tableau lemkeTableau, opts *Options, pivots int) *Solver {
+	solved := tableau.result(Solved, pivots)
+	solved.FactorPivots = pivots
+	solved.Ties = make([]int, pivots)
+	return &Solver{
+		tableau:      tableau,
+		opts:         opts,
+		status:       Solved,
+		pivots:       pivots,
+		factorPivots: pivots,
+		solved:       solved,
+	}
+}
+
+// Step pivots the entering variable in for the leaving one and finds
+// the next pair: the complement of whatever left enters, the ratio test
+// picks who leaves.  The Solver is done once z0 has left, a ray is
+// found or Options.MaxPivots pivots are made.  On ray termination Step
+// returns an error wrapping ErrRayTermination; any other error leaves
+// the Solver done and broken.  Stepping a Solver that is done does
+// nothing and returns the error it ended with, if any.
+func (s *Solver) Step() error {
+
+	if s.Done() {
+		return s.err
+	}
+
+	row, col, err := s.tableau.pivot(s.leave, s.enter)
+	if err != nil {
+		return s.fail(err)
+	}
+	s.pivots++
+	s.tieCounts = append(s.tieCounts, s.ties)
+	s.opts.notify(s.tableau, s.pivots, s.enter, s.leave, row, col, s.ties)
+
+	if s.z0leave {
+		s.status = Solved // z0 will have a value of zero but may still be basic... amend?
+		return nil
+	}
+
+	// selectpivot
+	enter, err := s.leave.complement()
+	if err != nil {
+		return s.fail(err)
+	}
+	s.enter = enter
+
+	s.leave, s.z0leave, s.ties, err = minratio(s.tableau, enter, s.rule)
+	if errors.Is(err, ErrRayTermination) {
+		s.status = RayTermination
+		s.err = fmt.Errorf("%w when trying to enter %s", ErrRayTermination, enter)
+		return s.err
+	} else if err != nil {
+		return s.fail(err)
+	}
+
+	if s.pivots == s.opts.MaxPivots { /* maxcount == 0 is equivalent to infinity since pivots start at 1 */
+		s.status = PivotLimit
+	}
+	return nil
+}
+
+// fail ends the run with an error other than ray termination.
+func (s *Solver) fail(err error) error {
+	s.err = err
+	return err
+}
+
+// Done reports whether the run has ended, see Result().Status for how.
+// A Solver broken by an error is done but still Running.
+func (s *Solver) Done() bool {
+	return s.status != Running || s.err != nil
+}
+
+// Pivots is the number of steps made so far.
+func (s *Solver) Pivots() int {
+	return s.pivots
+}
+
+// Basis is the basic variable of each tableau row.
+func (s *Solver) Basis() []Variable {
+	vars := s.tableau.variables()
+	basis := make([]Variable, vars.n)
+	for i := range basis {
+		basis[i] = vars.fromRow(i).variable()
+	}
+	return basis
+}
+
+// Tableau is a copy of the current integer tableau with the labels of
+// its rows and columns, nil for the block tableau of Options.BlockSize
+// and for a NewFieldSolver.
+func (s *Solver) Tableau() *Snapshot {
+	return s.tableau.snapshot()
+}
+
+// Solution is the current z1..zn.
+func (s *Solver) Solution() []*big.Rat {
+	return s.Result().Z
+}
+
+// Result is everything known about the current basis.  Its Status is
+// Running until the Solver is done.
+func (s *Solver) Result() *Result {
+
+	if s.solved != nil {
+		return s.solved
+	}
+
+	res := s.tableau.result(s.status, s.pivots)
+	res.FactorPivots = s.factorPivots
+	res.Ties = append([]int{}, s.tieCounts...)
+	if s.status == RayTermination {
+		res.Ray = s.tableau.ray(s.enter)
+	}
+	return res
+}
+
+// solve steps until done, checking ctx before every pivot.
+func (s *Solver) solve(ctx context.Context) (*Result, error) {
+
+	for !s.Done() {
+		if ctx.Err() != nil {
+			res := s.Result()
+			res.Status = Canceled
+			return res, &CanceledError{Result: res, Err: ctx.Err()}
+		}
+
+		if err := s.Step(); err != nil && !errors.Is(err, ErrRayTermination) {
+			return nil, err
+		}
+	}
+	return s.Result(), s.err
+}
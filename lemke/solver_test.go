@@ -0,0 +1,92 @@
+package lemke
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolverSteps(t *testing.T) {
+
+	M := ints2rats([]int{0, -1, 2, 2, 0, -2, -1, 1, 0})
+	q := ints2rats([]int{-3, 6, -1})
+	d := ints2rats([]int{1, 1, 1})
+	lcp := newTestLCP(t, M, q)
+
+	expected, err := SolveResult(lcp, d, nil)
+	assert.Nil(t, err)
+
+	s, err := NewSolver(lcp, d, nil)
+	assert.Nil(t, err)
+	assert.False(t, s.Done())
+	assert.Equal(t, []Variable{W(1), W(2), W(3)}, s.Basis())
+	assert.Equal(t, Running, s.Result().Status)
+
+	for !s.Done() {
+		before := s.Pivots()
+		assert.Nil(t, s.Step())
+		assert.Equal(t, before+1, s.Pivots())
+
+		snapshot := s.Tableau()
+		assert.Equal(t, s.Basis(), snapshot.Basis)
+		assert.Equal(t, 1, snapshot.Det.Sign())
+	}
+
+	res := s.Result()
+	assert.Equal(t, Solved, res.Status)
+	assert.Equal(t, expected.Pivots, res.Pivots)
+	assert.Equal(t, expected.Basis, s.Basis())
+	assert.Equal(t, solutionKey(expected.Z), solutionKey(s.Solution()))
+
+	// nothing left to do
+	assert.Nil(t, s.Step())
+	assert.Equal(t, expected.Pivots, s.Pivots())
+}
+
+func TestSolverPivotLimit(t *testing.T) {
+
+	lcp, d := randomTestLCP(t, 8, 2)
+	s, err := NewSolver(lcp, d, &Options{MaxPivots: 2})
+	assert.Nil(t, err)
+
+	assert.Nil(t, s.Step())
+	assert.False(t, s.Done())
+	assert.Nil(t, s.Step())
+	assert.True(t, s.Done())
+	assert.Equal(t, PivotLimit, s.Result().Status)
+}
+
+func TestSolverRayTermination(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{-1}), ints2rats([]int{-1}))
+	s, err := NewSolver(lcp, ints2rats([]int{1}), nil)
+	assert.Nil(t, err)
+
+	err = s.Step()
+	assert.True(t, errors.Is(err, ErrRayTermination))
+	assert.True(t, s.Done())
+	assert.Equal(t, RayTermination, s.Result().Status)
+	assert.NotNil(t, s.Result().Ray)
+	assert.True(t, errors.Is(s.Step(), ErrRayTermination))
+}
+
+func TestSolverWarmStartAlreadySolved(t *testing.T) {
+
+	lcp, d := randomTestLCP(t, 6, 4)
+	first, err := SolveResult(lcp, d, nil)
+	assert.Nil(t, err)
+
+	s, err := NewSolver(lcp, d, &Options{WarmStart: first.Basis})
+	assert.Nil(t, err)
+	assert.True(t, s.Done())
+	assert.Equal(t, Solved, s.Result().Status)
+	assert.Equal(t, solutionKey(first.Z), solutionKey(s.Solution()))
+}
+
+func TestSolverUnsupported(t *testing.T) {
+
+	lcp, d := randomTestLCP(t, 3, 1)
+	_, err := NewSolver(lcp, d, &Options{Method: PrincipalPivoting})
+	assert.True(t, errors.Is(err, ErrUnsupported))
+}
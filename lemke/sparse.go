@@ -0,0 +1,324 @@
+package lemke
+
+import (
+	"math/big"
+	"sort"
+)
+
+// StorageKind chooses how tableau entries are kept.
+type StorageKind int
+
+const (
+	// AutoStorage picks SparseStorage for large LCPs with few nonzeros,
+	// such as those of the sequence form, until pivots fill it in, and
+	// Int64Storage otherwise.
+	AutoStorage StorageKind = iota
+	// DenseStorage keeps all n(n+2) entries as big.Int.
+	DenseStorage
+	// SparseStorage keeps only the nonzeros of each row, sorted by column.
+	// Pivoting then costs time in the nonzeros touched, not in n^2.
+	SparseStorage
+	// Int64Storage keeps all entries in machine words and switches to
+	// DenseStorage for good once one of them overflows.
+	Int64Storage
+)
+
+// AutoStorage uses SparseStorage from this size on if at most a
+// sparseDensity share of the initial tableau is nonzero, and moves to
+// Int64Storage once fill-in has made more than a sparseFill share
+// nonzero.  Merging rows of big.Int only beats whole rows of machine
+// words while they stay short, see BenchmarkAutoStorage.
+const (
+	sparseMinSize = 64
+	sparseDensity = 0.1
+	sparseFill    = 0.2
+)
+
+// bigZero is returned for entries sparse storage does not keep.
+var bigZero = new(big.Int)
+
+/*
+ * sparseStorage
+ * ================================================================
+ * row compressed: row  i  holds the columns of its nonzeros in
+ * increasing order and their values.  A pivot rewrites every row with
+ * a nonzero in the pivot column as the merge of itself and the pivot
+ * row, all other rows are only rescaled and keep their pattern.
+ */
+type sparseStorage struct {
+	rows  []sparseRow
+	ncols int
+	fill  int
+}
+
+type sparseRow struct {
+	cols []int
+	vals []*big.Int
+}
+
+func newSparseStorage(nrows int, ncols int) *sparseStorage {
+	return &sparseStorage{
+		rows:  make([]sparseRow, nrows),
+		ncols: ncols,
+	}
+}
+
+// find is the position of col in the row and whether it is there
+func (r *sparseRow) find(col int) (int, bool) {
+	k := sort.SearchInts(r.cols, col)
+	return k, k < len(r.cols) && r.cols[k] == col
+}
+
+func (S *sparseStorage) entry(row int, col int) *big.Int {
+	r := &S.rows[row]
+	if k, ok := r.find(col); ok {
+		return r.vals[k]
+	}
+	return bigZero
+}
+
+func (S *sparseStorage) set(row int, col int, value *big.Int) {
+
+	r := &S.rows[row]
+	k, ok := r.find(col)
+	switch {
+	case ok && value.Sign() == 0:
+		r.cols = append(r.cols[:k], r.cols[k+1:]...)
+		r.vals = append(r.vals[:k], r.vals[k+1:]...)
+	case ok:
+		r.vals[k] = value
+	case value.Sign() != 0:
+		r.cols = append(r.cols, 0)
+		r.vals = append(r.vals, nil)
+		copy(r.cols[k+1:], r.cols[k:])
+		copy(r.vals[k+1:], r.vals[k:])
+		r.cols[k] = col
+		r.vals[k] = value
+	}
+}
+
+func (S *sparseStorage) fillIn() int {
+	return S.fill
+}
+
+// nonzeros is the number of entries kept.
+func (S *sparseStorage) nonzeros() int {
+	count := 0
+	for i := range S.rows {
+		count += len(S.rows[i].cols)
+	}
+	return count
+}
+
+func (S *sparseStorage) negateRow(row int) {
+	for _, value := range S.rows[row].vals {
+		value.Neg(value)
+	}
+}
+
+func (S *sparseStorage) negateCol(col int) {
+	for i := range S.rows {
+		r := &S.rows[i]
+		if k, ok := r.find(col); ok {
+			r.vals[k].Neg(r.vals[k])
+		}
+	}
+}
+
+/*
+ * same step as for dense storage, written with  s = sign(pivelt):
+ *   A[i][j] = s (A[i][j] pivelt - A[i][col] A[row][j]) / det
+ *   A[i][col] = -s A[i][col]
+ *   A[row][col] = det  and then row  row  negated if  s < 0
+ */
+func (S *sparseStorage) pivot(row int, col int, det *big.Int, workers int) *big.Int {
+
+	pivotRow := &S.rows[row]
+	k, _ := pivotRow.find(col)
+	pivelt := pivotRow.vals[k]
+	negpiv := pivelt.Sign() < 0
+	scale := new(big.Int).Abs(pivelt) /* new determinant  */
+
+	fill := make([]int, rowWorkers(len(S.rows), workers))
+	forRows(len(S.rows), workers, func(w int, lo int, hi int) {
+		for i := lo; i < hi; i++ {
+			if i == row {
+				continue
+			}
+
+			r := &S.rows[i]
+			entryCol := S.entry(i, col)
+			if entryCol.Sign() == 0 {
+				// A[i][j] = |pivelt| A[i][j] / det, pattern unchanged
+				for _, value := range r.vals {
+					value.Mul(value, scale)
+					value.Quo(value, det)
+				}
+				continue
+			}
+
+			var rowFill int
+			S.rows[i], rowFill = S.combine(r, pivotRow, col, pivelt, entryCol, det, negpiv)
+			fill[w] += rowFill
+		}
+	})
+	for _, f := range fill {
+		S.fill += f
+	}
+
+	pivotRow.vals[k] = new(big.Int).Set(det)
+	if negpiv {
+		S.negateRow(row)
+	}
+	return scale
+}
+
+// combine is the new row  r  after the pivot, a merge of  r  and the
+// pivot row  p  over their sorted columns, and its fill-in.
+func (S *sparseStorage) combine(r *sparseRow, p *sparseRow, col int, pivelt *big.Int, entryCol *big.Int, det *big.Int, negpiv bool) (sparseRow, int) {
+
+	merged := sparseRow{
+		cols: make([]int, 0, len(r.cols)+len(p.cols)),
+		vals: make([]*big.Int, 0, len(r.cols)+len(p.cols)),
+	}
+
+	a, b, fill := 0, 0, 0
+	for a < len(r.cols) || b < len(p.cols) {
+
+		j := S.ncols
+		if a < len(r.cols) {
+			j = r.cols[a]
+		}
+		if b < len(p.cols) && p.cols[b] < j {
+			j = p.cols[b]
+		}
+
+		value := new(big.Int)
+		inR := a < len(r.cols) && r.cols[a] == j
+		if j == col {
+			value.Set(entryCol)
+			if !negpiv {
+				value.Neg(value)
+			}
+		} else {
+			if inR {
+				value.Mul(r.vals[a], pivelt)
+			}
+			if b < len(p.cols) && p.cols[b] == j {
+				value.Sub(value, new(big.Int).Mul(entryCol, p.vals[b]))
+			}
+			value.Quo(value, det)
+			if negpiv {
+				value.Neg(value)
+			}
+		}
+
+		if value.Sign() != 0 {
+			merged.cols = append(merged.cols, j)
+			merged.vals = append(merged.vals, value)
+			if !inR {
+				fill++
+			}
+		}
+
+		if inR {
+			a++
+		}
+		if b < len(p.cols) && p.cols[b] == j {
+			b++
+		}
+	}
+	return merged, fill
+}
+
+// newStorage is the storage of the given kind for the tableau of the LCP
+// with covering vector d.
+func newStorage(lcp *LCP, d []*big.Rat, kind StorageKind) storage {
+
+	if kind == AutoStorage {
+		if sparseStart(lcp) {
+			return &autoStorage{storage: newSparseStorage(lcp.n, lcp.n+2)}
+		}
+		kind = Int64Storage
+	}
+
+	switch kind {
+	case SparseStorage:
+		return newSparseStorage(lcp.n, lcp.n+2)
+	case Int64Storage:
+		return newInt64Storage(lcp.n, lcp.n+2)
+	}
+	return newDenseStorage(lcp.n, lcp.n+2)
+}
+
+// sparseStart is whether AutoStorage starts out sparse for lcp.
+func sparseStart(lcp *LCP) bool {
+
+	if lcp.n < sparseMinSize {
+		return false
+	}
+	nonzeros := 0
+	for i := 0; i < lcp.n; i++ {
+		for j := 0; j < lcp.n; j++ {
+			if lcp.M(i, j).Sign() != 0 {
+				nonzeros++
+			}
+		}
+	}
+	return float64(nonzeros+2*lcp.n) <= sparseDensity*float64(lcp.n*(lcp.n+2))
+}
+
+/*
+ * autoStorage
+ * ================================================================
+ * sparse storage chosen by AutoStorage for a sparse initial tableau.
+ * Pivots fill it in, and once more than a sparseFill share of the
+ * entries is nonzero it copies them to int64Storage, which may still
+ * promote itself to big.Int, and pivots there from then on.
+ */
+type autoStorage struct {
+	storage
+	fill int // of the sparse storage, kept after moving
+}
+
+func (S *autoStorage) pivot(row int, col int, det *big.Int, workers int) *big.Int {
+
+	det = S.storage.pivot(row, col, det, workers)
+
+	sparse, ok := S.storage.(*sparseStorage)
+	if !ok {
+		return det
+	}
+	S.fill = sparse.fill
+	nrows := len(sparse.rows)
+	if float64(sparse.nonzeros()) > sparseFill*float64(nrows*sparse.ncols) {
+		dense := newInt64Storage(nrows, sparse.ncols)
+		for i := range sparse.rows {
+			r := &sparse.rows[i]
+			for k, j := range r.cols {
+				dense.set(i, j, r.vals[k])
+			}
+		}
+		S.storage = dense
+	}
+	return det
+}
+
+func (S *autoStorage) fillIn() int {
+	return S.fill
+}
+
+// sign and ratioTest are those of int64Storage once it took over.
+func (S *autoStorage) sign(row int, col int) (int, bool) {
+	if words, ok := S.storage.(wordStorage); ok {
+		return words.sign(row, col)
+	}
+	return 0, false
+}
+
+func (S *autoStorage) ratioTest(rowA int, rowB int, colA int, colB int) (int, bool) {
+	if words, ok := S.storage.(wordStorage); ok {
+		return words.ratioTest(rowA, rowB, colA, colB)
+	}
+	return 0, false
+}
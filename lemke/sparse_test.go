@@ -0,0 +1,199 @@
+package lemke
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparseSetAndEntry(t *testing.T) {
+
+	S := newSparseStorage(2, 4)
+	S.set(0, 2, big.NewInt(5))
+	S.set(0, 0, big.NewInt(3))
+	S.set(0, 1, big.NewInt(0))
+	assert.Equal(t, []int{0, 2}, S.rows[0].cols)
+	assert.Equal(t, int64(3), S.entry(0, 0).Int64())
+	assert.Equal(t, 0, S.entry(0, 1).Sign())
+	assert.Equal(t, 0, S.entry(1, 3).Sign())
+
+	S.set(0, 0, big.NewInt(0))
+	assert.Equal(t, []int{2}, S.rows[0].cols)
+
+	S.negateCol(2)
+	assert.Equal(t, int64(-5), S.entry(0, 2).Int64())
+}
+
+// the same pivots on dense and sparse storage give the same tableau
+func TestSparsePivotMatchesDense(t *testing.T) {
+
+	r := rand.New(rand.NewSource(3))
+	n := 6
+	dense := newTableau(n)
+	sparse := newTableauWith(n, newSparseStorage(n, n+2))
+	for i := 0; i < n; i++ {
+		for j := 0; j < n+2; j++ {
+			value := int64(0)
+			if r.Intn(3) == 0 {
+				value = int64(r.Intn(11) - 5)
+			}
+			dense.set(i, j, big.NewInt(value))
+			sparse.set(i, j, big.NewInt(value))
+		}
+	}
+
+	for step := 0; step < 20; step++ {
+		row, col := r.Intn(n), r.Intn(n+1)
+		if dense.entry(row, col).Sign() == 0 {
+			continue
+		}
+		assert.Nil(t, dense.pivotMatrix(row, col))
+		assert.Nil(t, sparse.pivotMatrix(row, col))
+		assert.Equal(t, dense.det.String(), sparse.det.String())
+		for i := 0; i < n; i++ {
+			for j := 0; j < n+2; j++ {
+				assert.Equal(t, dense.entry(i, j).String(), sparse.entry(i, j).String())
+			}
+		}
+	}
+	assert.True(t, sparse.store.fillIn() > 0)
+}
+
+func TestSparseSolveMatchesDense(t *testing.T) {
+
+	for seed := int64(1); seed <= 5; seed++ {
+		lcp, d := randomTestLCP(t, 10, seed)
+
+		expected, err := SolveResult(lcp, d, &Options{Storage: DenseStorage})
+		assert.Nil(t, err)
+		res, err := SolveResult(lcp, d, &Options{Storage: SparseStorage})
+		assert.Nil(t, err)
+
+		assert.Equal(t, solutionKey(expected.Z), solutionKey(res.Z))
+		assert.Equal(t, expected.Basis, res.Basis)
+		assert.Equal(t, expected.Pivots, res.Pivots)
+		assert.Equal(t, 0, expected.FillIn)
+
+		res, err = SolveResult(lcp, nil, &Options{Method: PrincipalPivoting, Storage: SparseStorage})
+		assert.Nil(t, err)
+		assert.Equal(t, solutionKey(expected.Z), solutionKey(res.Z))
+	}
+}
+
+func TestAutoStorage(t *testing.T) {
+
+	n := sparseMinSize
+	M := make([]int, n*n)
+	q := make([]int, n)
+	for i := 0; i < n; i++ {
+		M[i*n+i] = 2
+		M[i*n+(i+1)%n] = -1
+		q[i] = -1
+	}
+	lcp := newTestLCP(t, ints2rats(M), ints2rats(q))
+	d := ints2rats(q)
+	for i := range d {
+		d[i].SetInt64(1)
+	}
+
+	auto, isAuto := newStorage(lcp, d, AutoStorage).(*autoStorage)
+	assert.True(t, isAuto)
+	_, isSparse := auto.storage.(*sparseStorage)
+	assert.True(t, isSparse)
+	_, isAuto = newStorage(lcp, d, DenseStorage).(*autoStorage)
+	assert.False(t, isAuto)
+
+	small, smallD := randomTestLCP(t, 4, 1)
+	_, isInt64 := newStorage(small, smallD, AutoStorage).(*int64Storage)
+	assert.True(t, isInt64)
+
+	// the cyclic band fills in completely, so the tableau moves to words
+	tableau, scaleFactors, err := createTableau(lcp, d, AutoStorage)
+	assert.Nil(t, err)
+	res, err := run(context.Background(), tableau, scaleFactors, &Options{})
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, res.Status)
+	for i := 0; i < n; i++ {
+		assert.Equal(t, "1", res.Z[i].RatString())
+	}
+	_, isInt64 = tableau.store.(*autoStorage).storage.(*int64Storage)
+	assert.True(t, isInt64)
+	assert.True(t, res.FillIn > 0)
+
+	expected, err := SolveResult(lcp, d, &Options{Storage: DenseStorage})
+	assert.Nil(t, err)
+	assert.Equal(t, expected.Basis, res.Basis)
+	assert.Equal(t, expected.Pivots, res.Pivots)
+}
+
+// BenchmarkAutoStorage backs sparseFill: a band that fills in completely
+// pivots far faster in machine words, a scattered one that stays a few
+// percent nonzero is faster kept sparse.
+func BenchmarkAutoStorage(b *testing.B) {
+
+	band := bandTestLCP(&testing.T{}, 150)
+	scattered := scatteredTestLCP(&testing.T{}, 400, 3, 1)
+	for _, bench := range []struct {
+		name string
+		lcp  *LCP
+	}{{"band", band}, {"scattered", scattered}} {
+		for _, kind := range []StorageKind{AutoStorage, SparseStorage, Int64Storage} {
+			b.Run(fmt.Sprintf("%s/%s", bench.name, storageNames[kind]), func(b *testing.B) {
+				d := make([]*big.Rat, bench.lcp.n)
+				for i := range d {
+					d[i] = big.NewRat(1, 1)
+				}
+				for i := 0; i < b.N; i++ {
+					tableau, scaleFactors, _ := createTableau(bench.lcp, d, kind)
+					run(context.Background(), tableau, scaleFactors, &Options{})
+				}
+			})
+		}
+	}
+}
+
+var storageNames = map[StorageKind]string{
+	AutoStorage:   "auto",
+	SparseStorage: "sparse",
+	Int64Storage:  "int64",
+}
+
+// bandTestLCP is the tridiagonal LCP with 2 on the diagonal, -1 next to
+// it and q = -1, whose tableau fills in completely.
+func bandTestLCP(t *testing.T, n int) *LCP {
+
+	M := make([]int, n*n)
+	q := make([]int, n)
+	for i := 0; i < n; i++ {
+		M[i*n+i] = 2
+		if i > 0 {
+			M[i*n+i-1] = -1
+		}
+		if i+1 < n {
+			M[i*n+i+1] = -1
+		}
+		q[i] = -1
+	}
+	return newTestLCP(t, ints2rats(M), ints2rats(q))
+}
+
+// scatteredTestLCP has a dominant diagonal and  perRow  small entries
+// in random columns of every row.
+func scatteredTestLCP(t *testing.T, n int, perRow int, seed int64) *LCP {
+
+	r := rand.New(rand.NewSource(seed))
+	M := make([]int, n*n)
+	q := make([]int, n)
+	for i := 0; i < n; i++ {
+		M[i*n+i] = n
+		for k := 0; k < perRow; k++ {
+			M[i*n+r.Intn(n)] = r.Intn(9) - 4
+		}
+		q[i] = r.Intn(5) - 3
+	}
+	return newTestLCP(t, ints2rats(M), ints2rats(q))
+}
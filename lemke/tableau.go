@@ -8,30 +8,71 @@ import (
 )
 
 type tableau struct {
-	matrix []*big.Int
-	ncols  int
-	nrows  int
-	vars   *tableauVariables
-	det    *big.Int // determinant
+	store storage
+	ncols int
+	nrows int
+	vars  *tableauVariables
+	det   *big.Int // determinant
+
+	workers int // goroutines for the row updates of a pivot, see forRows
+}
+
+// storage holds the entries of a tableau, densely or sparsely.
+type storage interface {
+	// entry must not be changed by the caller, use set
+	entry(row int, col int) *big.Int
+	set(row int, col int, value *big.Int)
+	// pivot does the integer pivoting step on the nonzero element at
+	// row, col and returns the new determinant, updating the other rows
+	// on up to  workers  goroutines
+	pivot(row int, col int, det *big.Int, workers int) *big.Int
+	negateRow(row int)
+	negateCol(col int)
+	// fillIn is the number of zeros pivoting has made nonzero so far
+	fillIn() int
 }
 
 func newTableau(n int) *tableau {
+	return newTableauWith(n, newDenseStorage(n, n+2))
+}
+
+func newTableauWith(n int, store storage) *tableau {
 	tableau := &tableau{}
 	tableau.nrows = n
 	tableau.ncols = n + 2
 	tableau.vars = newTableauVariables(n)
-	tableau.matrix = make([]*big.Int, tableau.nrows*tableau.ncols)
+	tableau.store = store
 
 	tableau.det = big.NewInt(-1) // TODO: how do I know this? Specific to LCP?
 	return tableau
 }
 
 func (A *tableau) set(row int, col int, value *big.Int) {
-	A.matrix[row*A.ncols+col] = value
+	A.store.set(row, col, value)
 }
 
 func (A *tableau) entry(row int, col int) *big.Int {
-	return A.matrix[row*A.ncols+col]
+	return A.store.entry(row, col)
+}
+
+// wordStorage is storage that can answer signs and ratio tests without
+// going through big.Int, as int64Storage does until it is promoted.
+type wordStorage interface {
+	sign(row int, col int) (int, bool)
+	ratioTest(rowA int, rowB int, colA int, colB int) (int, bool)
+}
+
+func (A *tableau) sign(row int, col int) int {
+	if words, ok := A.store.(wordStorage); ok {
+		if sign, ok := words.sign(row, col); ok {
+			return sign
+		}
+	}
+	return A.entry(row, col).Sign()
+}
+
+func (A *tableau) variables() *tableauVariables {
+	return A.vars
 }
 
 /*
@@ -40,70 +81,34 @@ func (A *tableau) entry(row int, col int) *big.Int {
  * and updated tableau variables
  * @param leave (r) VAR defining row of pivot element
  * @param enter (s) VAR defining col of pivot element
+ * @return the row and col of the pivot element
  */
-func (A *tableau) pivot(leave *tableauVariable, enter *tableauVariable) {
+func (A *tableau) pivot(leave *tableauVariable, enter *tableauVariable) (int, int, error) {
 
 	if !leave.isBasic() {
-		panic(fmt.Sprintf("%v is not in the basis", leave))
+		return 0, 0, fmt.Errorf("%w: %v is not in the basis", ErrBadPivot, leave)
 	}
 
 	if enter.isBasic() {
-		panic(fmt.Sprintf("%v is already in the basis", enter))
+		return 0, 0, fmt.Errorf("%w: %v is already in the basis", ErrBadPivot, enter)
 	}
 
-	row, col := A.vars.swap(enter, leave) /* update tableau variables                                  */
-	fmt.Println("pivoting (", row, ",", col, ")")
-	A.pivotMatrix(row, col)
-}
-
-func (A *tableau) pivotMatrix(row int, col int) {
-
-	pivelt := A.entry(row, col) /* pivelt anyhow later new determinant  */
-
-	if pivelt.Sign() == 0 {
-		panic("Trying to pivot on a zero")
+	if A.entry(leave.row(), enter.col()).Sign() == 0 {
+		return 0, 0, fmt.Errorf("%w: %v cannot replace %v on a zero", ErrBadPivot, enter, leave)
 	}
 
-	negpiv := false
-	if pivelt.Sign() < 0 {
-		negpiv = true
-		pivelt.Neg(pivelt)
-	}
+	row, col := A.vars.swap(enter, leave) /* update tableau variables                                  */
+	return row, col, A.pivotMatrix(row, col)
+}
 
-	for i := 0; i < A.nrows; i++ {
-		if i != row { // A[row][..] remains unchanged
-			entry := A.entry(i, col)
-			nonzero := entry.Sign() != 0
-			for j := 0; j < A.ncols; j++ {
-				if j != col {
-
-					//A[i,j] = (A[i,j] A[row,col] - A[i,col] A[row,j]) / det
-					entryIJ := A.entry(i, j)
-					entryIJ.Mul(entryIJ, pivelt)
-					if nonzero {
-						tmp := new(big.Int).Mul(entry, A.entry(row, j))
-						if negpiv {
-							entryIJ.Add(entryIJ, tmp)
-						} else {
-							entryIJ.Sub(entryIJ, tmp)
-						}
-					}
-					entryIJ.Div(entryIJ, A.det)
-				}
-			}
-			if nonzero && !negpiv {
-				/* row  i  has been dealt with, update  A[i][col]  safely   */
-				entry.Neg(entry)
-			}
-		}
-	}
+func (A *tableau) pivotMatrix(row int, col int) error {
 
-	A.set(row, col, A.det)
-	if negpiv {
-		A.negateRow(row)
+	if A.entry(row, col).Sign() == 0 {
+		return fmt.Errorf("%w: trying to pivot on a zero", ErrBadPivot)
 	}
 
-	A.det = pivelt //by construction always positive
+	A.det = A.store.pivot(row, col, A.det, A.workers) //by construction always positive
+	return nil
 }
 
 /*
@@ -111,27 +116,22 @@ func (A *tableau) pivotMatrix(row int, col int) {
  * (assumes only positive entries of col are considered)
  */
 func (A *tableau) ratioTest(rowA int, rowB int, colA int, colB int) int {
+	if words, ok := A.store.(wordStorage); ok {
+		if cmp, ok := words.ratioTest(rowA, rowB, colA, colB); ok {
+			return cmp
+		}
+	}
 	a := new(big.Int).Mul(A.entry(rowA, colB), A.entry(rowB, colA))
 	b := new(big.Int).Mul(A.entry(rowB, colB), A.entry(rowA, colA))
 	return a.Cmp(b)
 }
 
 func (A *tableau) negateRow(row int) {
-	for j := 0; j < A.ncols; j++ {
-		entry := A.entry(row, j)
-		if entry.Sign() != 0 {
-			entry.Neg(entry)
-		}
-	}
+	A.store.negateRow(row)
 }
 
 func (A *tableau) negateCol(col int) {
-	for i := 0; i < A.nrows; i++ {
-		entry := A.entry(i, col)
-		if entry.Sign() != 0 {
-			entry.Neg(entry)
-		}
-	}
+	A.store.negateCol(col)
 }
 
 func (A *tableau) rhsCol() int {
@@ -167,3 +167,102 @@ func (A *tableau) String() string {
 	table.Print(&buffer)
 	return buffer.String()
 }
+
+// denseStorage keeps every entry, row by row.
+type denseStorage struct {
+	matrix []*big.Int
+	ncols  int
+	nrows  int
+}
+
+func newDenseStorage(nrows int, ncols int) *denseStorage {
+	return &denseStorage{
+		matrix: make([]*big.Int, nrows*ncols),
+		ncols:  ncols,
+		nrows:  nrows,
+	}
+}
+
+func (S *denseStorage) set(row int, col int, value *big.Int) {
+	S.matrix[row*S.ncols+col] = value
+}
+
+func (S *denseStorage) entry(row int, col int) *big.Int {
+	return S.matrix[row*S.ncols+col]
+}
+
+func (S *denseStorage) fillIn() int {
+	return 0
+}
+
+func (S *denseStorage) pivot(row int, col int, det *big.Int, workers int) *big.Int {
+
+	pivelt := S.entry(row, col) /* pivelt anyhow later new determinant  */
+
+	negpiv := false
+	if pivelt.Sign() < 0 {
+		negpiv = true
+		pivelt.Neg(pivelt)
+	}
+
+	forRows(S.nrows, workers, func(_ int, lo int, hi int) {
+		for i := lo; i < hi; i++ {
+			if i != row { // A[row][..] remains unchanged
+				S.pivotRow(i, row, col, pivelt, det, negpiv)
+			}
+		}
+	})
+
+	S.set(row, col, det)
+	if negpiv {
+		S.negateRow(row)
+	}
+
+	return pivelt
+}
+
+func (S *denseStorage) pivotRow(i int, row int, col int, pivelt *big.Int, det *big.Int, negpiv bool) {
+
+	entry := S.entry(i, col)
+	nonzero := entry.Sign() != 0
+	tmp := new(big.Int)
+	for j := 0; j < S.ncols; j++ {
+		if j != col {
+
+			//A[i,j] = (A[i,j] A[row,col] - A[i,col] A[row,j]) / det
+			entryIJ := S.entry(i, j)
+			entryIJ.Mul(entryIJ, pivelt)
+			if nonzero {
+				tmp.Mul(entry, S.entry(row, j))
+				if negpiv {
+					entryIJ.Add(entryIJ, tmp)
+				} else {
+					entryIJ.Sub(entryIJ, tmp)
+				}
+			}
+			entryIJ.Div(entryIJ, det)
+		}
+	}
+	if nonzero && !negpiv {
+		/* row  i  has been dealt with, update  A[i][col]  safely   */
+		entry.Neg(entry)
+	}
+}
+
+func (S *denseStorage) negateRow(row int) {
+	for j := 0; j < S.ncols; j++ {
+		entry := S.entry(row, j)
+		if entry.Sign() != 0 {
+			entry.Neg(entry)
+		}
+	}
+}
+
+func (S *denseStorage) negateCol(col int) {
+	for i := 0; i < S.nrows; i++ {
+		entry := S.entry(i, col)
+		if entry.Sign() != 0 {
+			entry.Neg(entry)
+		}
+	}
+}
@@ -33,23 +33,62 @@ func (tvar *tableauVariable) isZ0() bool {
  * complement of  v  in VARS, error if  v==Z(0).
  * this is  W(i) for Z(i)  and vice versa, i=1...n
  */
-func (tvar *tableauVariable) complement() *tableauVariable {
+func (tvar *tableauVariable) complement() (*tableauVariable, error) {
 
 	if tvar.idx == 0 {
-		panic("Attempt to find complement of z0.")
+		return nil, fmt.Errorf("%w: attempt to find complement of z0", ErrBadPivot)
 	}
 
 	if tvar.isZ() {
-		return &tvar.s.lookup[tvar.idx+tvar.s.n]
+		return &tvar.s.lookup[tvar.idx+tvar.s.n], nil
 	}
-	return &tvar.s.lookup[tvar.idx-tvar.s.n]
+	return &tvar.s.lookup[tvar.idx-tvar.s.n], nil
 }
 
 func (tvar *tableauVariable) String() string {
+	return tvar.variable().String()
+}
+
+func (tvar *tableauVariable) variable() Variable {
 	if tvar.isZ() {
-		return fmt.Sprintf("z%d", tvar.idx)
+		return Z(tvar.idx)
 	}
-	return fmt.Sprintf("w%d", tvar.idx-tvar.s.n)
+	return W(tvar.idx - tvar.s.n)
+}
+
+// Variable names one of the LCP variables z0, z1..zn or w1..wn outside of a
+// tableau.  Non-negative values are z variables and negative values are
+// w variables, so Z(3) == 3 and W(3) == -3.
+type Variable int
+
+// Z returns the variable z(i), i = 0..n
+func Z(i int) Variable {
+	return Variable(i)
+}
+
+// W returns the variable w(i), i = 1..n
+func W(i int) Variable {
+	return Variable(-i)
+}
+
+// IsZ reports whether v is one of z0..zn.
+func (v Variable) IsZ() bool {
+	return v >= 0
+}
+
+// Index is the subscript of v, e.g. 3 for both z3 and w3.
+func (v Variable) Index() int {
+	if v < 0 {
+		return int(-v)
+	}
+	return int(v)
+}
+
+func (v Variable) String() string {
+	if v.IsZ() {
+		return fmt.Sprintf("z%d", v.Index())
+	}
+	return fmt.Sprintf("w%d", v.Index())
 }
 
 /* tableauVariables
@@ -102,6 +141,17 @@ func (vars *tableauVariables) w(subscript int) *tableauVariable {
 	return &vars.lookup[subscript+vars.n]
 }
 
+// lookupVariable finds the tableau variable for v, or nil if v is not one of them.
+func (vars *tableauVariables) lookupVariable(v Variable) *tableauVariable {
+	if v.IsZ() && v.Index() <= vars.n {
+		return vars.z(v.Index())
+	}
+	if !v.IsZ() && v.Index() <= vars.n {
+		return vars.w(v.Index())
+	}
+	return nil
+}
+
 func (vars *tableauVariables) fromRow(row int) *tableauVariable {
 	return &vars.lookup[vars.fromRowCol[row]]
 }
@@ -52,11 +52,11 @@ func TestComplement(t *testing.T) {
 	vars := newTableauVariables(4)
 
 	for i := 1; i <= vars.n; i++ {
-		compVar := vars.z(i).complement()
+		compVar, err := vars.z(i).complement()
+		assert.Nil(t, err)
 		assert.Equal(t, vars.w(i).idx, compVar.idx, "w(i) should be complement of z(i)")
 	}
 
-	// TODO: assert panic?
-	// _, err := lcp.complement(lcp.z(0))
-	// assert.NotNil(t, err, "Should not be able to get complement of z0")
+	_, err := vars.z(0).complement()
+	assert.NotNil(t, err, "Should not be able to get complement of z0")
 }
@@ -0,0 +1,197 @@
+package lemke
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// ViolationKind is the condition of an LCP that a vector violates.
+type ViolationKind int
+
+const (
+	// NegativeZ means z_i < 0.
+	NegativeZ ViolationKind = iota
+	// NegativeSlack means w_i = (Mz + q)_i < 0.
+	NegativeSlack
+	// NotComplementary means z_i w_i != 0.
+	NotComplementary
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case NegativeZ:
+		return "z < 0"
+	case NegativeSlack:
+		return "Mz + q < 0"
+	case NotComplementary:
+		return "z (Mz + q) != 0"
+	}
+	return "unknown"
+}
+
+// Violation is one condition violated at index i (from 0) of z.
+type Violation struct {
+	Kind    ViolationKind
+	Index   int
+	Z       *big.Rat
+	Slack   *big.Rat // (Mz + q)_i
+	Product *big.Rat // z_i times Slack
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%v at %d: z = %s, w = %s, z w = %s", v.Kind, v.Index+1, v.Z.RatString(), v.Slack.RatString(), v.Product.RatString())
+}
+
+// Report is what Verify found.
+type Report struct {
+	Slack      []*big.Rat // Mz + q
+	Violations []Violation
+}
+
+// OK reports whether z solves the LCP.
+func (r *Report) OK() bool {
+	return len(r.Violations) == 0
+}
+
+// Verify checks exactly that  z >= 0,  w = Mz + q >= 0  and  z'w = 0,
+// reporting every violation in order of index, and for each index first
+// the sign conditions.  The error is only for a z of the wrong size.
+func Verify(lcp *LCP, z []*big.Rat) (*Report, error) {
+
+	if len(z) != lcp.n {
+		return nil, fmt.Errorf("%w: z has %d entries but the LCP is of size %d", ErrDimension, len(z), lcp.n)
+	}
+
+	report := &Report{Slack: make([]*big.Rat, lcp.n)}
+	tmp := new(big.Rat)
+	for i := 0; i < lcp.n; i++ {
+		w := new(big.Rat).Set(lcp.q[i])
+		for j := 0; j < lcp.n; j++ {
+			w.Add(w, tmp.Mul(lcp.M(i, j), z[j]))
+		}
+		report.Slack[i] = w
+
+		product := new(big.Rat).Mul(z[i], w)
+		violation := func(kind ViolationKind) {
+			report.Violations = append(report.Violations, Violation{
+				Kind:    kind,
+				Index:   i,
+				Z:       z[i],
+				Slack:   w,
+				Product: product,
+			})
+		}
+
+		if z[i].Sign() < 0 {
+			violation(NegativeZ)
+		}
+		if w.Sign() < 0 {
+			violation(NegativeSlack)
+		}
+		if product.Sign() != 0 {
+			violation(NotComplementary)
+		}
+	}
+	return report, nil
+}
+
+// FloatViolation is a Violation of a float64 vector.
+type FloatViolation struct {
+	Kind    ViolationKind
+	Index   int
+	Z       float64
+	Slack   float64
+	Product float64
+}
+
+func (v FloatViolation) String() string {
+	return fmt.Sprintf("%v at %d: z = %g, w = %g, z w = %g", v.Kind, v.Index+1, v.Z, v.Slack, v.Product)
+}
+
+// FloatReport is what VerifyFloat found.
+type FloatReport struct {
+	Slack      []float64
+	Violations []FloatViolation
+}
+
+// OK reports whether z solves the LCP within the tolerance.
+func (r *FloatReport) OK() bool {
+	return len(r.Violations) == 0
+}
+
+// VerifyFloat is Verify for a float64 vector, e.g. from another solver:
+// each z_i stands for the rational it represents exactly, so Mz + q and
+// z_i w_i are computed exactly and only then held against tol.  A
+// condition is violated by more than tol, so z_i < -tol, w_i < -tol or
+// |z_i w_i| > tol, and an infinite or NaN entry of z violates every
+// condition it enters.  tol must be finite.
+func VerifyFloat(lcp *LCP, z []float64, tol float64) (*FloatReport, error) {
+
+	if len(z) != lcp.n {
+		return nil, fmt.Errorf("%w: z has %d entries but the LCP is of size %d", ErrDimension, len(z), lcp.n)
+	}
+	bound := new(big.Rat)
+	if bound.SetFloat64(tol) == nil {
+		return nil, fmt.Errorf("%w: tolerance %g", ErrField, tol)
+	}
+	negBound := new(big.Rat).Neg(bound)
+
+	exact := make([]*big.Rat, lcp.n) // nil where z is not finite
+	for j, value := range z {
+		exact[j] = new(big.Rat).SetFloat64(value)
+	}
+
+	report := &FloatReport{Slack: make([]float64, lcp.n)}
+	tmp := new(big.Rat)
+	for i := 0; i < lcp.n; i++ {
+		slack := new(big.Rat).Set(lcp.q[i])
+		finite := true
+		for j := 0; j < lcp.n && finite; j++ {
+			m := lcp.M(i, j)
+			if m.Sign() == 0 {
+				continue
+			}
+			if exact[j] == nil {
+				finite = false
+				continue
+			}
+			slack.Add(slack, tmp.Mul(m, exact[j]))
+		}
+
+		w := math.NaN()
+		if finite {
+			w, _ = slack.Float64()
+		}
+		report.Slack[i] = w
+
+		product := z[i] * w
+		complementary := false
+		if finite && exact[i] != nil {
+			tmp.Mul(exact[i], slack)
+			product, _ = tmp.Float64()
+			complementary = tmp.Abs(tmp).Cmp(bound) <= 0
+		}
+
+		violation := func(kind ViolationKind) {
+			report.Violations = append(report.Violations, FloatViolation{
+				Kind:    kind,
+				Index:   i,
+				Z:       z[i],
+				Slack:   w,
+				Product: product,
+			})
+		}
+
+		if z[i] < -tol || math.IsNaN(z[i]) {
+			violation(NegativeZ)
+		}
+		if !finite || slack.Cmp(negBound) < 0 {
+			violation(NegativeSlack)
+		}
+		if !complementary {
+			violation(NotComplementary)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,89 @@
+package lemke
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySolution(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{-1, -1}))
+	z, err := Solve(lcp, ints2rats([]int{2, 1}))
+	assert.Nil(t, err)
+
+	report, err := Verify(lcp, z)
+	assert.Nil(t, err)
+	assert.True(t, report.OK())
+	assert.Equal(t, "0 0 ", solutionKey(report.Slack))
+}
+
+func TestVerifyViolations(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{-1, -1}))
+
+	// w = (2, 3), neither product is zero
+	z := []*big.Rat{big.NewRat(1, 1), big.NewRat(1, 1)}
+	report, err := Verify(lcp, z)
+	assert.Nil(t, err)
+	assert.False(t, report.OK())
+	assert.Equal(t, 2, len(report.Violations))
+	for i, v := range report.Violations {
+		assert.Equal(t, NotComplementary, v.Kind)
+		assert.Equal(t, i, v.Index)
+	}
+	assert.Equal(t, "2", report.Violations[0].Slack.RatString())
+	assert.Equal(t, "2", report.Violations[0].Product.RatString())
+
+	z = []*big.Rat{big.NewRat(-1, 2), big.NewRat(0, 1)}
+	report, err = Verify(lcp, z)
+	assert.Nil(t, err)
+	assert.Equal(t, []ViolationKind{NegativeZ, NegativeSlack, NotComplementary, NegativeSlack}, kinds(report.Violations))
+	assert.Equal(t, "-2", report.Violations[1].Slack.RatString())
+	assert.Equal(t, "1", report.Violations[2].Product.RatString())
+
+	_, err = Verify(lcp, z[:1])
+	assert.True(t, errors.Is(err, ErrDimension))
+}
+
+func kinds(violations []Violation) []ViolationKind {
+	result := make([]ViolationKind, len(violations))
+	for i, v := range violations {
+		result[i] = v.Kind
+	}
+	return result
+}
+
+func TestVerifyFloat(t *testing.T) {
+
+	lcp := newTestLCP(t, ints2rats([]int{2, 1, 1, 3}), ints2rats([]int{-1, -1}))
+
+	// z = (2/5, 1/5) rounded
+	report, err := VerifyFloat(lcp, []float64{0.4000001, 0.1999999}, 1e-6)
+	assert.Nil(t, err)
+	assert.True(t, report.OK())
+
+	report, err = VerifyFloat(lcp, []float64{0.41, 0.2}, 1e-6)
+	assert.Nil(t, err)
+	assert.False(t, report.OK())
+	assert.Equal(t, NotComplementary, report.Violations[0].Kind)
+	assert.InDelta(t, 0.02, report.Violations[0].Slack, 1e-12)
+
+	// q = -(2^60 + 1) rounds to -2^60 in float64, which z = 2^60 solves
+	lcp = newTestLCP(t, ints2rats([]int{1}), ints2rats([]int{-(1<<60 + 1)}))
+	report, err = VerifyFloat(lcp, []float64{1 << 60}, 0.5)
+	assert.Nil(t, err)
+	assert.False(t, report.OK())
+	assert.Equal(t, NegativeSlack, report.Violations[0].Kind)
+	assert.Equal(t, -1.0, report.Slack[0])
+
+	report, err = VerifyFloat(lcp, []float64{math.NaN()}, 0.5)
+	assert.Nil(t, err)
+	assert.Len(t, report.Violations, 3)
+
+	_, err = VerifyFloat(lcp, []float64{1}, math.Inf(1))
+	assert.ErrorIs(t, err, ErrField)
+}
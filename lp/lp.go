@@ -0,0 +1,187 @@
+// Package lp solves linear and convex quadratic programs exactly in
+// rationals.
+//
+//	maximize  c'x  subject to  Ax <= b,  x >= 0
+//
+// The optimality conditions of the LP and its dual
+//
+//	minimize  b'y  subject to  A'y >= c,  y >= 0
+//
+// form an LCP with a skew symmetric, hence positive semidefinite, matrix
+// that lemke solves with integer pivoting and the lexicographic ratio
+// test, so degenerate LPs cannot cycle.  The KKT conditions of a convex
+// quadratic program give an LCP of the same shape, see MinimizeQuadratic.
+package lp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/megesdal/gametheory/lemke"
+)
+
+// Status tells how an LP was resolved.
+type Status int
+
+const (
+	// Optimal means X and Y are optimal and Value is the optimum.
+	Optimal Status = iota
+	// Infeasible means no x satisfies Ax <= b, x >= 0.
+	Infeasible
+	// Unbounded means the objective is unbounded on a nonempty feasible
+	// set.
+	Unbounded
+)
+
+func (s Status) String() string {
+	switch s {
+	case Optimal:
+		return "optimal"
+	case Infeasible:
+		return "infeasible"
+	case Unbounded:
+		return "unbounded"
+	}
+	return "unknown"
+}
+
+// Solution is the outcome of Maximize or MinimizeQuadratic.  X, Y and
+// Value are only set if the program is Optimal.
+type Solution struct {
+	Status Status
+	X      []*big.Rat // primal solution, one per column of A
+	Y      []*big.Rat // dual values or multipliers, one per row of A
+	Value  *big.Rat   // optimal value of the objective
+}
+
+// Maximize solves  max c'x  s.t.  Ax <= b,  x >= 0  where the m x n
+// matrix A is given row by row, m = len(b) and n = len(c).
+func Maximize(A []*big.Rat, b []*big.Rat, c []*big.Rat) (*Solution, error) {
+	return MaximizeContext(context.Background(), A, b, c)
+}
+
+// MaximizeContext is Maximize but stops with a *lemke.CanceledError once
+// ctx is done.
+func MaximizeContext(ctx context.Context, A []*big.Rat, b []*big.Rat, c []*big.Rat) (*Solution, error) {
+
+	m, n := len(b), len(c)
+	if n == 0 || len(A) != m*n {
+		return nil, fmt.Errorf("%w: A has %d entries for %d rows and %d cols", lemke.ErrDimension, len(A), m, n)
+	}
+
+	g := make([]*big.Rat, n)
+	for j := range g {
+		g[j] = new(big.Rat).Neg(c[j])
+	}
+	sol, err := solve(ctx, nil, A, b, g)
+	if err != nil || sol.Status != Optimal {
+		return sol, err
+	}
+
+	tmp := new(big.Rat)
+	for j, x := range sol.X {
+		sol.Value.Add(sol.Value, tmp.Mul(c[j], x))
+	}
+	return sol, nil
+}
+
+// solve finds the optimal x and duals y of  min 1/2 x'Qx + g'x  s.t.
+// Ax <= b,  x >= 0  for a positive semidefinite and symmetric Q, nil for
+// a linear objective.  Value is left zero.
+func solve(ctx context.Context, Q []*big.Rat, A []*big.Rat, b []*big.Rat, g []*big.Rat) (*Solution, error) {
+
+	n := len(g)
+	z, err := solveOptimality(ctx, Q, A, b, g)
+	if err == nil {
+		return &Solution{
+			Status: Optimal,
+			X:      z[:n],
+			Y:      z[n:],
+			Value:  new(big.Rat),
+		}, nil
+	}
+	if !errors.Is(err, lemke.ErrRayTermination) {
+		return nil, err
+	}
+
+	// With M positive semidefinite Lemke only ends on a ray if the LCP
+	// has no solution, i.e. the program is infeasible or unbounded.  For
+	// the zero objective it cannot be unbounded, so this tells which.
+	zero := make([]*big.Rat, n)
+	for j := range zero {
+		zero[j] = new(big.Rat)
+	}
+	_, err = solveOptimality(ctx, nil, A, b, zero)
+	switch {
+	case err == nil:
+		return &Solution{Status: Unbounded}, nil
+	case errors.Is(err, lemke.ErrRayTermination):
+		return &Solution{Status: Infeasible}, nil
+	}
+	return nil, err
+}
+
+/*
+ * solveOptimality
+ * ================================================================
+ * the LCP of the KKT conditions in  z = (x, y)  with
+ *     w = [  Q  A' ] z + [ g ]
+ *         [ -A  0  ]     [ b ]
+ * i.e.  Qx + A'y + g >= 0  complementary to  x >= 0  and  b >= Ax  to
+ * y >= 0.
+ */
+func solveOptimality(ctx context.Context, Q []*big.Rat, A []*big.Rat, b []*big.Rat, g []*big.Rat) ([]*big.Rat, error) {
+
+	m, n := len(b), len(g)
+	size := n + m
+	M := make([]*big.Rat, size*size)
+	for k := range M {
+		M[k] = new(big.Rat)
+	}
+	q := make([]*big.Rat, size)
+	d := make([]*big.Rat, size)
+
+	if Q != nil {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				M[i*size+j].Set(Q[i*n+j])
+			}
+		}
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			M[j*size+n+i].Set(A[i*n+j])
+			M[(n+i)*size+j].Neg(A[i*n+j])
+		}
+	}
+	for j := 0; j < n; j++ {
+		q[j] = new(big.Rat).Set(g[j])
+	}
+	for i := 0; i < m; i++ {
+		q[n+i] = new(big.Rat).Set(b[i])
+	}
+	for k := range d {
+		d[k] = big.NewRat(1, 1)
+	}
+
+	lcp, err := lemke.NewLCP(M, q)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := lemke.SolveResultContext(ctx, lcp, d, nil)
+	if errors.Is(err, lemke.ErrTrivialSolution) {
+		// g >= 0 and b >= 0: x = 0 and y = 0 are optimal
+		z := make([]*big.Rat, size)
+		for k := range z {
+			z[k] = new(big.Rat)
+		}
+		return z, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Z, nil
+}
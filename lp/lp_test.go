@@ -0,0 +1,143 @@
+package lp
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/megesdal/gametheory/lemke"
+	"github.com/stretchr/testify/assert"
+)
+
+func ints2rats(values []int) []*big.Rat {
+	rats := make([]*big.Rat, len(values))
+	for i, v := range values {
+		rats[i] = big.NewRat(int64(v), 1)
+	}
+	return rats
+}
+
+func ratStrings(rats []*big.Rat) []string {
+	strs := make([]string, len(rats))
+	for i, r := range rats {
+		strs[i] = r.RatString()
+	}
+	return strs
+}
+
+func TestMaximize(t *testing.T) {
+
+	// max 3x1 + 5x2  s.t.  x1 <= 4,  2x2 <= 12,  3x1 + 2x2 <= 18
+	A := ints2rats([]int{
+		1, 0,
+		0, 2,
+		3, 2,
+	})
+	b := ints2rats([]int{4, 12, 18})
+	c := ints2rats([]int{3, 5})
+
+	sol, err := Maximize(A, b, c)
+	assert.Nil(t, err)
+	assert.Equal(t, Optimal, sol.Status)
+	assert.Equal(t, []string{"2", "6"}, ratStrings(sol.X))
+	assert.Equal(t, []string{"0", "3/2", "1"}, ratStrings(sol.Y))
+	assert.Equal(t, "36", sol.Value.RatString())
+}
+
+func TestMaximizeDegenerate(t *testing.T) {
+
+	// the optimum x = (1, 0) is overdetermined by three constraints
+	A := ints2rats([]int{
+		1, 1,
+		1, 0,
+		2, 1,
+	})
+	b := ints2rats([]int{1, 1, 2})
+	c := ints2rats([]int{2, 1})
+
+	sol, err := Maximize(A, b, c)
+	assert.Nil(t, err)
+	assert.Equal(t, Optimal, sol.Status)
+	assert.Equal(t, "2", sol.Value.RatString())
+
+	dual := new(big.Rat)
+	for i, y := range sol.Y {
+		assert.True(t, y.Sign() >= 0)
+		dual.Add(dual, new(big.Rat).Mul(b[i], y))
+	}
+	assert.Equal(t, "2", dual.RatString())
+}
+
+func TestMaximizeTrivial(t *testing.T) {
+
+	A := ints2rats([]int{1, 1})
+	b := ints2rats([]int{3})
+	c := ints2rats([]int{-1, 0})
+
+	sol, err := Maximize(A, b, c)
+	assert.Nil(t, err)
+	assert.Equal(t, Optimal, sol.Status)
+	assert.Equal(t, []string{"0", "0"}, ratStrings(sol.X))
+	assert.Equal(t, []string{"0"}, ratStrings(sol.Y))
+	assert.Equal(t, "0", sol.Value.RatString())
+}
+
+func TestMaximizeInfeasible(t *testing.T) {
+
+	// x1 + x2 <= -1 has no nonnegative solution
+	A := ints2rats([]int{1, 1})
+	b := ints2rats([]int{-1})
+	c := ints2rats([]int{1, 1})
+
+	sol, err := Maximize(A, b, c)
+	assert.Nil(t, err)
+	assert.Equal(t, Infeasible, sol.Status)
+	assert.Nil(t, sol.X)
+}
+
+func TestMaximizeUnbounded(t *testing.T) {
+
+	// x1 - x2 <= 1 lets x2 and then x1 grow without limit
+	A := ints2rats([]int{1, -1})
+	b := ints2rats([]int{1})
+	c := ints2rats([]int{1, 1})
+
+	sol, err := Maximize(A, b, c)
+	assert.Nil(t, err)
+	assert.Equal(t, Unbounded, sol.Status)
+	assert.Nil(t, sol.X)
+}
+
+func TestMaximizeZeroSum(t *testing.T) {
+
+	// the column player of  [[3, -1], [-2, 1]]  shifted to be positive,
+	// whose value 1/v is the optimum of  max 1'x  s.t.  Ax <= 1
+	A := ints2rats([]int{
+		7, 3,
+		2, 5,
+	})
+	b := ints2rats([]int{1, 1})
+	c := ints2rats([]int{1, 1})
+
+	sol, err := Maximize(A, b, c)
+	assert.Nil(t, err)
+	assert.Equal(t, Optimal, sol.Status)
+	assert.Equal(t, []string{"2/29", "5/29"}, ratStrings(sol.X))
+	assert.Equal(t, []string{"3/29", "4/29"}, ratStrings(sol.Y))
+	assert.Equal(t, "7/29", sol.Value.RatString())
+}
+
+func TestMaximizeDimension(t *testing.T) {
+
+	_, err := Maximize(ints2rats([]int{1, 2, 3}), ints2rats([]int{1}), ints2rats([]int{1, 1}))
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+
+	_, err = Maximize(nil, ints2rats([]int{1}), nil)
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+}
+
+func TestStatusString(t *testing.T) {
+	assert.Equal(t, "optimal", Optimal.String())
+	assert.Equal(t, "infeasible", Infeasible.String())
+	assert.Equal(t, "unbounded", Unbounded.String())
+}
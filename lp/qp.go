@@ -0,0 +1,90 @@
+package lp
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/megesdal/gametheory/lemke"
+)
+
+// MinimizeQuadratic solves the convex quadratic program
+//
+//	minimize  1/2 x'Qx + c'x  subject to  Ax <= b,  x >= 0
+//
+// where the n x n matrix Q and the m x n matrix A are given row by row,
+// m = len(b) and n = len(c).  Only the symmetric part of Q counts, and it
+// must be positive semidefinite, else the error wraps
+// lemke.ErrMatrixClass and names an x with x'Qx < 0.  Y holds the
+// multipliers of  Ax <= b.
+func MinimizeQuadratic(Q []*big.Rat, A []*big.Rat, b []*big.Rat, c []*big.Rat) (*Solution, error) {
+	return MinimizeQuadraticContext(context.Background(), Q, A, b, c)
+}
+
+// MinimizeQuadraticContext is MinimizeQuadratic but stops with a
+// *lemke.CanceledError once ctx is done.
+func MinimizeQuadraticContext(ctx context.Context, Q []*big.Rat, A []*big.Rat, b []*big.Rat, c []*big.Rat) (*Solution, error) {
+
+	m, n := len(b), len(c)
+	if n == 0 || len(A) != m*n {
+		return nil, fmt.Errorf("%w: A has %d entries for %d rows and %d cols", lemke.ErrDimension, len(A), m, n)
+	}
+	if len(Q) != n*n {
+		return nil, fmt.Errorf("%w: Q has %d entries for %d cols", lemke.ErrDimension, len(Q), n)
+	}
+
+	S := make([]*big.Rat, n*n)
+	half := big.NewRat(1, 2)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			S[i*n+j] = new(big.Rat).Add(Q[i*n+j], Q[j*n+i])
+			S[i*n+j].Mul(S[i*n+j], half)
+		}
+	}
+
+	if err := checkSemidefinite(S, n); err != nil {
+		return nil, err
+	}
+
+	sol, err := solve(ctx, S, A, b, c)
+	if err != nil || sol.Status != Optimal {
+		return sol, err
+	}
+
+	tmp := new(big.Rat)
+	for i, xi := range sol.X {
+		for j, xj := range sol.X {
+			tmp.Mul(xi, xj)
+			sol.Value.Add(sol.Value, tmp.Mul(tmp, S[i*n+j]))
+		}
+	}
+	sol.Value.Mul(sol.Value, half)
+	for j, x := range sol.X {
+		sol.Value.Add(sol.Value, tmp.Mul(c[j], x))
+	}
+	return sol, nil
+}
+
+func checkSemidefinite(S []*big.Rat, n int) error {
+
+	q := make([]*big.Rat, n)
+	for i := range q {
+		q[i] = new(big.Rat)
+	}
+	lcp, err := lemke.NewLCP(S, q)
+	if err != nil {
+		return err
+	}
+
+	report := lcp.IsPositiveSemidefinite()
+	if report.Holds {
+		return nil
+	}
+
+	x := make([]string, n)
+	for i, v := range report.Witness.Vector {
+		x[i] = v.RatString()
+	}
+	return fmt.Errorf("%w: Q is not positive semidefinite, x'Qx < 0 for x = (%s)", lemke.ErrMatrixClass, strings.Join(x, ", "))
+}
@@ -0,0 +1,103 @@
+package lp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/megesdal/gametheory/lemke"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinimizeQuadratic(t *testing.T) {
+
+	// min 1/2 (x1^2 + x2^2) - x1 - x2  s.t.  x1 + x2 <= 1
+	Q := ints2rats([]int{
+		1, 0,
+		0, 1,
+	})
+	A := ints2rats([]int{1, 1})
+	b := ints2rats([]int{1})
+	c := ints2rats([]int{-1, -1})
+
+	sol, err := MinimizeQuadratic(Q, A, b, c)
+	assert.Nil(t, err)
+	assert.Equal(t, Optimal, sol.Status)
+	assert.Equal(t, []string{"1/2", "1/2"}, ratStrings(sol.X))
+	assert.Equal(t, []string{"1/2"}, ratStrings(sol.Y))
+	assert.Equal(t, "-3/4", sol.Value.RatString())
+}
+
+func TestMinimizeQuadraticInterior(t *testing.T) {
+
+	// the constraint is slack at the unconstrained minimum (1, 2)
+	Q := ints2rats([]int{
+		2, 0,
+		0, 2,
+	})
+	A := ints2rats([]int{1, 1})
+	b := ints2rats([]int{10})
+	c := ints2rats([]int{-2, -4})
+
+	sol, err := MinimizeQuadratic(Q, A, b, c)
+	assert.Nil(t, err)
+	assert.Equal(t, Optimal, sol.Status)
+	assert.Equal(t, []string{"1", "2"}, ratStrings(sol.X))
+	assert.Equal(t, []string{"0"}, ratStrings(sol.Y))
+	assert.Equal(t, "-5", sol.Value.RatString())
+}
+
+func TestMinimizeQuadraticNonsymmetric(t *testing.T) {
+
+	// only the symmetric part [[2, 1], [1, 2]] counts
+	sym, err := MinimizeQuadratic(ints2rats([]int{2, 1, 1, 2}), nil, nil, ints2rats([]int{-3, 0}))
+	assert.Nil(t, err)
+	Q := ints2rats([]int{
+		2, 2,
+		0, 2,
+	})
+	sol, err := MinimizeQuadratic(Q, nil, nil, ints2rats([]int{-3, 0}))
+	assert.Nil(t, err)
+	assert.Equal(t, Optimal, sol.Status)
+	assert.Equal(t, ratStrings(sym.X), ratStrings(sol.X))
+	assert.Equal(t, []string{"3/2", "0"}, ratStrings(sol.X))
+	assert.Equal(t, "-9/4", sol.Value.RatString())
+}
+
+func TestMinimizeQuadraticNotSemidefinite(t *testing.T) {
+
+	Q := ints2rats([]int{
+		1, 0,
+		0, -1,
+	})
+	_, err := MinimizeQuadratic(Q, ints2rats([]int{1, 1}), ints2rats([]int{1}), ints2rats([]int{0, 0}))
+	assert.True(t, errors.Is(err, lemke.ErrMatrixClass))
+}
+
+func TestMinimizeQuadraticInfeasible(t *testing.T) {
+
+	Q := ints2rats([]int{
+		1, 0,
+		0, 1,
+	})
+	sol, err := MinimizeQuadratic(Q, ints2rats([]int{1, 1}), ints2rats([]int{-1}), ints2rats([]int{0, 0}))
+	assert.Nil(t, err)
+	assert.Equal(t, Infeasible, sol.Status)
+}
+
+func TestMinimizeQuadraticUnbounded(t *testing.T) {
+
+	// x2 does not appear in the quadratic term
+	Q := ints2rats([]int{
+		1, 0,
+		0, 0,
+	})
+	sol, err := MinimizeQuadratic(Q, nil, nil, ints2rats([]int{0, -1}))
+	assert.Nil(t, err)
+	assert.Equal(t, Unbounded, sol.Status)
+}
+
+func TestMinimizeQuadraticDimension(t *testing.T) {
+
+	_, err := MinimizeQuadratic(ints2rats([]int{1}), nil, nil, ints2rats([]int{1, 1}))
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+}
@@ -0,0 +1,9 @@
+package nash
+
+import "errors"
+
+var (
+	// ErrNoEquilibrium means Lemke stopped at Options.MaxPivots on every
+	// attempt without reaching an equilibrium.
+	ErrNoEquilibrium = errors.New("nash: no equilibrium within the pivot limit")
+)
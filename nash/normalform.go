@@ -2,7 +2,9 @@ package nash
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"math/rand"
 
@@ -17,6 +19,8 @@ type Equilibrium struct {
 
 	colProbs []*big.Rat //length = #cols (1 per strategy)
 	colPay   *big.Rat
+
+	attempts int // covering vectors Lemke tried
 }
 
 func newEquilibrium(rowProbs []*big.Rat, colProbs []*big.Rat, fnPayoff func(int, int, int) *big.Rat) *Equilibrium {
@@ -46,6 +50,12 @@ func (eq *Equilibrium) computeExpectedPayoffs(nrows int, ncols int, fnPayoff fun
 	}
 }
 
+// Attempts is the number of priors Lemke was run with to find the
+// equilibrium, more than one only after restarts, see Options.
+func (eq *Equilibrium) Attempts() int {
+	return eq.attempts
+}
+
 func (eq *Equilibrium) String() string {
 	var buf bytes.Buffer
 
@@ -86,6 +96,28 @@ func (eq *Equilibrium) String() string {
 // The lookup would be P3[i][j][k] = P1[(i * ncols + j) * 2 + k], ncols = 2
 //
 func LemkeEquilibrium(payoffs [][][]float64, seed int64) (*Equilibrium, error) {
+	return LemkeEquilibriumContext(context.Background(), payoffs, seed)
+}
+
+// LemkeEquilibriumContext is LemkeEquilibrium but stops with a
+// *lemke.CanceledError once ctx is done.
+func LemkeEquilibriumContext(ctx context.Context, payoffs [][][]float64, seed int64) (*Equilibrium, error) {
+	return LemkeEquilibriumWithOptions(ctx, payoffs, seed, nil)
+}
+
+// Options tunes LemkeEquilibriumWithOptions.
+type Options struct {
+	// Restarts is how many more times Lemke is run with fresh random
+	// priors, drawn from the seed, if it ends on a ray or at MaxPivots.
+	Restarts int
+
+	// MaxPivots caps every run, 0 means no cap.
+	MaxPivots int
+}
+
+// LemkeEquilibriumWithOptions is LemkeEquilibriumContext configured by
+// opts, which may be nil.
+func LemkeEquilibriumWithOptions(ctx context.Context, payoffs [][][]float64, seed int64, opts *Options) (*Equilibrium, error) {
 
 	nrows := len(payoffs)
 	if nrows == 0 {
@@ -97,30 +129,48 @@ func LemkeEquilibrium(payoffs [][][]float64, seed int64) (*Equilibrium, error) {
 		return nil, errors.New("Cannot have a payoff matrix with 0 cols")
 	}
 
+	for i := 0; i < nrows; i++ {
+		if len(payoffs[i]) != ncols {
+			return nil, fmt.Errorf("%w: row %d has %d cols, expected %d", lemke.ErrDimension, i, len(payoffs[i]), ncols)
+		}
+		for j := 0; j < ncols; j++ {
+			if len(payoffs[i][j]) != 2 {
+				return nil, fmt.Errorf("%w: payoff at (%d, %d) has %d entries, expected 2", lemke.ErrDimension, i, j, len(payoffs[i][j]))
+			}
+		}
+	}
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
 	// set priors to randomly choose a strategy with Pr=1
 	r := rand.New(rand.NewSource(seed))
+	rowPriors := randomPurePriors(r, nrows)
+	colPriors := randomPurePriors(r, ncols)
 
-	rowPriors := make([]*big.Rat, nrows)
-	rowPr1 := r.Intn(nrows)
-	for i := 0; i < nrows; i++ {
-		if i == rowPr1 {
-			rowPriors[i] = one()
-		} else {
-			rowPriors[i] = zero()
-		}
+	lemkeOpts := &lemke.Options{
+		MaxPivots: opts.MaxPivots,
+		Restarts:  opts.Restarts,
 	}
+	return lemkeEquilibrium(ctx, convertToRats(payoffs), rowPriors, colPriors, lemkeOpts, func(lcp *lemke.LCP) []*big.Rat {
+		return generateCovVector(lcp, randomPurePriors(r, nrows), randomPurePriors(r, ncols))
+	})
+}
 
-	colPriors := make([]*big.Rat, ncols)
-	colPr1 := r.Intn(ncols)
-	for j := 0; j < ncols; j++ {
-		if j == colPr1 {
-			colPriors[j] = one()
+// randomPurePriors puts probability one on a random strategy
+func randomPurePriors(r *rand.Rand, n int) []*big.Rat {
+
+	priors := make([]*big.Rat, n)
+	pr1 := r.Intn(n)
+	for i := 0; i < n; i++ {
+		if i == pr1 {
+			priors[i] = one()
 		} else {
-			colPriors[j] = zero()
+			priors[i] = zero()
 		}
 	}
-
-	return LemkeEquilibriumWithPriors(convertToRats(payoffs), rowPriors, colPriors)
+	return priors
 }
 
 func convertToRats(payoffs64 [][][]float64) []*big.Rat {
@@ -142,25 +192,84 @@ func convertToRats(payoffs64 [][][]float64) []*big.Rat {
 // LemkeEquilibriumWithPriors runs the Lemke algorithm using the given prior
 // beliefs to compute the covering vector.
 func LemkeEquilibriumWithPriors(payoffs []*big.Rat, rowPriors []*big.Rat, colPriors []*big.Rat) (*Equilibrium, error) {
+	return LemkeEquilibriumWithPriorsContext(context.Background(), payoffs, rowPriors, colPriors)
+}
+
+// LemkeEquilibriumWithPriorsContext is LemkeEquilibriumWithPriors but stops
+// with a *lemke.CanceledError once ctx is done.
+func LemkeEquilibriumWithPriorsContext(ctx context.Context, payoffs []*big.Rat, rowPriors []*big.Rat, colPriors []*big.Rat) (*Equilibrium, error) {
 
 	nrows := len(rowPriors)
 	ncols := len(colPriors)
+	if nrows == 0 || ncols == 0 || len(payoffs) != nrows*ncols*2 {
+		return nil, fmt.Errorf("%w: %d payoffs for %d rows and %d cols", lemke.ErrDimension, len(payoffs), nrows, ncols)
+	}
+
+	return lemkeEquilibrium(ctx, payoffs, rowPriors, colPriors, &lemke.Options{}, nil)
+}
+
+// LemkeLCP is the LCP and covering vector that LemkeEquilibriumWithPriors
+// solves, e.g. to run lemke on it with other options.  The first
+// len(rowPriors) entries of its solution z are the row player's mixed
+// strategy and, after one more, the next len(colPriors) the column
+// player's.
+func LemkeLCP(payoffs []*big.Rat, rowPriors []*big.Rat, colPriors []*big.Rat) (*lemke.LCP, []*big.Rat, error) {
+
+	nrows := len(rowPriors)
+	ncols := len(colPriors)
+	if nrows == 0 || ncols == 0 || len(payoffs) != nrows*ncols*2 {
+		return nil, nil, fmt.Errorf("%w: %d payoffs for %d rows and %d cols", lemke.ErrDimension, len(payoffs), nrows, ncols)
+	}
+
+	lcp, err := payoffLCP(payoffs, nrows, ncols)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lcp, generateCovVector(lcp, rowPriors, colPriors), nil
+}
+
+// payoffLCP adjusts the payoffs to be strictly negative (max = -1) and
+// generates the LCP from the two payoff matrices.
+func payoffLCP(payoffs []*big.Rat, nrows int, ncols int) (*lemke.LCP, error) {
 
-	// 1. Adjust the payoffs to be strictly negative (max = -1)
 	adjustedPayoffs := correctPaymentsNeg(payoffs)
 	fnAdjustedPayoff := func(row int, col int, pl int) *big.Rat {
 		return adjustedPayoffs[(row*ncols+col)*2+pl]
 	}
+	return generateLCP(nrows, ncols, fnAdjustedPayoff)
+}
 
+// lemkeEquilibrium runs Lemke with opts, where  restart, if not nil,
+// gives the covering vectors of restarts.
+func lemkeEquilibrium(ctx context.Context, payoffs []*big.Rat, rowPriors []*big.Rat, colPriors []*big.Rat, opts *lemke.Options, restart func(lcp *lemke.LCP) []*big.Rat) (*Equilibrium, error) {
+
+	nrows := len(rowPriors)
+	ncols := len(colPriors)
+
+	// 1. Adjust the payoffs to be strictly negative (max = -1)
 	// 2. Generate the LCP from the two payoff matrices and the priors
-	lcp := generateLCP(nrows, ncols, fnAdjustedPayoff)
+	lcp, err := payoffLCP(payoffs, nrows, ncols)
+	if err != nil {
+		return nil, err
+	}
 	d := generateCovVector(lcp, rowPriors, colPriors)
 
-	// 3. Pass the combination of the two to the Lemke algorithm
-	z, err := lemke.Solve(lcp, d)
+	// 3. Pass the combination of the two to the Lemke algorithm, which
+	// pivots the two players' halves of M as separate blocks
+	opts.BlockSize = nrows + 1
+	if restart != nil {
+		opts.CoveringVector = func(*rand.Rand) []*big.Rat {
+			return restart(lcp)
+		}
+	}
+	res, err := lemke.SolveResultContext(ctx, lcp, d, opts)
 	if err != nil {
 		return nil, err
 	}
+	if res.Status == lemke.PivotLimit {
+		return nil, fmt.Errorf("%w: %d attempts of %d pivots", ErrNoEquilibrium, res.Attempts, res.Pivots)
+	}
+	z := res.Z
 
 	// 4. Convert solution into a mixed strategy equilibrium
 	pl1, pl2 := extractLCPSolution(z, nrows)
@@ -171,6 +280,7 @@ func LemkeEquilibriumWithPriors(payoffs []*big.Rat, rowPriors []*big.Rat, colPri
 	}
 
 	eq := newEquilibrium(pl1, pl2, fnPayoff)
+	eq.attempts = res.Attempts
 
 	return eq, nil
 }
@@ -216,7 +326,7 @@ func applyPayCorrect(payoffs []*big.Rat, corrections [2]*big.Rat) []*big.Rat {
 }
 
 // this assumes pays have been normalized to -1 as the max value
-func generateLCP(nrows int, ncols int, fnPayoff func(int, int, int) *big.Rat) *lemke.LCP {
+func generateLCP(nrows int, ncols int, fnPayoff func(int, int, int) *big.Rat) (*lemke.LCP, error) {
 
 	size := nrows + ncols + 2
 
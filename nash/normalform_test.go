@@ -1,9 +1,15 @@
 package nash
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
 	"testing"
 
+	"github.com/megesdal/gametheory/lemke"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,3 +29,118 @@ func TestLemke(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "rows 1/1 0/1 0/1=11/1\ncols 1/1 0/1 0/1 0/1=3/1", eq.String())
 }
+
+func TestLemkeDimensionErrors(t *testing.T) {
+
+	_, err := LemkeEquilibrium([][][]float64{{{1, 2}, {3, 4}}, {{5, 6}}}, int64(1))
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+
+	_, err = LemkeEquilibrium([][][]float64{{{1, 2}, {3}}}, int64(1))
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+
+	_, err = LemkeEquilibriumWithPriors(nil, []*big.Rat{one()}, []*big.Rat{one()})
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+}
+
+func TestLemkeContextCanceled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := LemkeEquilibriumContext(ctx, [][][]float64{{{1, 0}, {0, 1}}, {{0, 1}, {1, 0}}}, int64(1))
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	var canceled *lemke.CanceledError
+	assert.True(t, errors.As(err, &canceled))
+	assert.Equal(t, 0, canceled.Result.Pivots)
+}
+
+func TestLemkeBlockMatchesFullTableau(t *testing.T) {
+
+	r := rand.New(rand.NewSource(3))
+	for game := 0; game < 20; game++ {
+		nrows, ncols := 2+r.Intn(4), 2+r.Intn(4)
+		payoffs := make([]*big.Rat, nrows*ncols*2)
+		for i := range payoffs {
+			payoffs[i] = big.NewRat(int64(r.Intn(4)), 1)
+		}
+		rowPriors := make([]*big.Rat, nrows)
+		for i := range rowPriors {
+			rowPriors[i] = big.NewRat(1, int64(nrows))
+		}
+		colPriors := make([]*big.Rat, ncols)
+		for j := range colPriors {
+			colPriors[j] = big.NewRat(1, int64(ncols))
+		}
+
+		// LemkeEquilibriumWithPriors pivots on the blocks
+		eq, err := LemkeEquilibriumWithPriors(payoffs, rowPriors, colPriors)
+		assert.Nil(t, err)
+
+		lcp, d, err := LemkeLCP(payoffs, rowPriors, colPriors)
+		assert.Nil(t, err)
+		full, err := lemke.SolveResult(lcp, d, nil)
+		assert.Nil(t, err)
+		block, err := lemke.SolveResult(lcp, d, &lemke.Options{BlockSize: nrows + 1})
+		assert.Nil(t, err)
+
+		assert.Equal(t, full.Basis, block.Basis)
+		assert.Equal(t, full.Pivots, block.Pivots)
+		assert.Equal(t, fmt.Sprint(full.Z), fmt.Sprint(block.Z))
+
+		pl1, pl2 := extractLCPSolution(full.Z, nrows)
+		assert.Equal(t, fmt.Sprint(pl1, pl2), fmt.Sprint(eq.rowProbs, eq.colProbs))
+	}
+}
+
+func TestLemkeRestartsAtPivotLimit(t *testing.T) {
+
+	var payMatrix [][][]float64
+	json.Unmarshal([]byte(`
+        [ [ [ 11, 3 ], [ 3, 0 ], [ 11, 3 ], [  3, 0 ] ],
+          [ [  0, 2 ], [ 0, 7 ], [ 12, 0 ], [ 12, 5 ] ],
+		  [ [  6, 0 ], [ 6, 0 ], [  0, 1 ], [  0, 1 ] ] ]`), &payMatrix)
+
+	// seed 1 needs 9 pivots, other priors fewer
+	_, err := LemkeEquilibriumWithOptions(context.Background(), payMatrix, int64(1), &Options{MaxPivots: 7})
+	assert.True(t, errors.Is(err, ErrNoEquilibrium))
+
+	eq, err := LemkeEquilibriumWithOptions(context.Background(), payMatrix, int64(1), &Options{MaxPivots: 7, Restarts: 20})
+	assert.Nil(t, err)
+	assert.True(t, eq.Attempts() > 1)
+
+	again, err := LemkeEquilibriumWithOptions(context.Background(), payMatrix, int64(1), &Options{MaxPivots: 7, Restarts: 20})
+	assert.Nil(t, err)
+	assert.Equal(t, eq.String(), again.String())
+	assert.Equal(t, eq.Attempts(), again.Attempts())
+
+	eq, err = LemkeEquilibrium(payMatrix, int64(2))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, eq.Attempts())
+}
+
+func TestLemkeLCP(t *testing.T) {
+
+	payoffs := []*big.Rat{
+		big.NewRat(3, 1), big.NewRat(2, 1), zero(), zero(),
+		zero(), zero(), big.NewRat(2, 1), big.NewRat(3, 1),
+	}
+	priors := []*big.Rat{one(), zero()}
+
+	eq, err := LemkeEquilibriumWithPriors(payoffs, priors, priors)
+	assert.Nil(t, err)
+
+	lcp, d, err := LemkeLCP(payoffs, priors, priors)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, lcp.N())
+
+	z, err := lemke.Solve(lcp, d)
+	assert.Nil(t, err)
+	rows, cols := extractLCPSolution(z, 2)
+	assert.Equal(t, eq.String(), newEquilibrium(rows, cols, func(row int, col int, pl int) *big.Rat {
+		return payoffs[(row*2+col)*2+pl]
+	}).String())
+
+	_, _, err = LemkeLCP(payoffs, priors, []*big.Rat{one()})
+	assert.True(t, errors.Is(err, lemke.ErrDimension))
+}
@@ -28,7 +28,9 @@ type SequenceForm struct {
 	priors map[*Move]*big.Rat
 }
 
-func NewSequenceForm(nf *NodeFactory) *SequenceForm {
+// NewSequenceForm builds the sequence form of the game tree rooted at nf.
+// Returns an *ImperfectRecallError if some player forgets own moves.
+func NewSequenceForm(nf *NodeFactory) (*SequenceForm, error) {
 	sf := new(SequenceForm)
 	//sf.seqIndex = make(map[string]int)
 	//sf.isetIndex = make(map[string]int)
@@ -44,7 +46,10 @@ func NewSequenceForm(nf *NodeFactory) *SequenceForm {
 	sf.plSeqs = make(map[string][]string)
 
 	fmt.Println("=====START seqform====")
-	sf.recVisitNode(0, big.NewRat(1, 1), nf, make(map[string]*MoveFactory))
+	err := sf.recVisitNode(0, big.NewRat(1, 1), nf, make(map[string]*MoveFactory))
+	if err != nil {
+		return nil, err
+	}
 
 	// sort plSeqs and plIsets
 	for pl, seqs := range sf.plSeqs {
@@ -65,23 +70,26 @@ func NewSequenceForm(nf *NodeFactory) *SequenceForm {
 		By(isetDepth).Sort(isets)
 	}
 
-	return sf
+	return sf, nil
 }
 
-func (sf *SequenceForm) recVisitNode(depth int, prob *big.Rat, nf *NodeFactory, sequences map[string]*MoveFactory) {
+func (sf *SequenceForm) recVisitNode(depth int, prob *big.Rat, nf *NodeFactory, sequences map[string]*MoveFactory) error {
 
 	lastMove := sequences[nf.Player]
 
 	if !nf.Chance {
 		sf.addPlayerIfAbsent(nf.Player)
-		sf.addOrVerifyInformationSet(nf.Player, nf.Iset, lastMove)
+		err := sf.addOrVerifyInformationSet(nf.Player, nf.Iset, lastMove)
+		if err != nil {
+			return err
+		}
 	}
 
 	for _, move := range nf.Moves {
 
 		if move.Name == "\u2205" {
 			// TODO: quote it or replace it?
-			panic("Use of reserved name for empty sequence...")
+			return fmt.Errorf("%w: move of player %s at %s", ErrReservedName, nf.Player, nf.Iset)
 		}
 
 		if !nf.Chance {
@@ -103,11 +111,15 @@ func (sf *SequenceForm) recVisitNode(depth int, prob *big.Rat, nf *NodeFactory,
 			sf.plConstraints[nf.Player][nf.Iset][move.Name] = 1
 		}
 		sequences[nf.Player] = move
-		sf.followMove(depth, prob, move, sequences)
+		err := sf.followMove(depth, prob, move, sequences)
+		if err != nil {
+			return err
+		}
 	}
 
 	// pop the seq stack...
 	sequences[nf.Player] = lastMove
+	return nil
 }
 
 func (sf *SequenceForm) addPlayerIfAbsent(pl string) {
@@ -133,7 +145,7 @@ func (sf *SequenceForm) addPlayerIfAbsent(pl string) {
 	}
 }
 
-func (sf *SequenceForm) addOrVerifyInformationSet(pl string, iset string, lastMove *MoveFactory) {
+func (sf *SequenceForm) addOrVerifyInformationSet(pl string, iset string, lastMove *MoveFactory) error {
 
 	isetsForPl := sf.plIsets[pl]
 	isetExists := false
@@ -163,12 +175,18 @@ func (sf *SequenceForm) addOrVerifyInformationSet(pl string, iset string, lastMo
 
 	// if iset existed, this should already be set to -1, else we have imperfect recall..
 	if isetExists && sf.plConstraints[pl][iset][lastMoveName] != -1 {
-		panic("Imperfect Recall!!!") // TODO: switch to return an error...
+		return &ImperfectRecallError{
+			Player:   pl,
+			Iset:     iset,
+			Seq:      lastMoveName,
+			Expected: sf.plIsetDefSeqs[pl][iset],
+		}
 	}
 
 	// constraint: iset -> lastMove -> -1
 	sf.plConstraints[pl][iset][lastMoveName] = -1
 	sf.plIsetDefSeqs[pl][iset] = lastMoveName
+	return nil
 }
 
 func (sf *SequenceForm) payoffSeqKey(sequences map[string]*MoveFactory, except string) string {
@@ -187,7 +205,7 @@ func (sf *SequenceForm) payoffSeqKey(sequences map[string]*MoveFactory, except s
 	return key
 }
 
-func (sf *SequenceForm) followMove(depth int, prob *big.Rat, mf *MoveFactory, sequences map[string]*MoveFactory) {
+func (sf *SequenceForm) followMove(depth int, prob *big.Rat, mf *MoveFactory, sequences map[string]*MoveFactory) error {
 	//fmt.Println(depth, prob, "move", mf.String())
 
 	var nextProb *big.Rat
@@ -199,7 +217,7 @@ func (sf *SequenceForm) followMove(depth int, prob *big.Rat, mf *MoveFactory, se
 	}
 
 	if mf.Next != nil {
-		sf.recVisitNode(depth+1, nextProb, mf.Next, sequences)
+		return sf.recVisitNode(depth+1, nextProb, mf.Next, sequences)
 	} else if mf.Outcome != nil {
 		// looking
 		for _, outcome := range mf.Outcome {
@@ -226,6 +244,7 @@ func (sf *SequenceForm) followMove(depth int, prob *big.Rat, mf *MoveFactory, se
 			sf.plPayoffs[pl][seq.Name][othersKey] = payoffRat //new(big.Rat).Mul(payoffRat, prob)  // TODO: store the prob somewhere...?
 		}
 	}
+	return nil
 }
 
 // sequence sorting...
@@ -2,7 +2,6 @@ package gametheory
 
 import (
 	"bytes"
-	"fmt"
 	"github.com/megesdal/matrixprinter"
 	"strings"
 )
@@ -58,22 +57,17 @@ func (sf *SequenceForm) recAppendRow(table *matrixprinter.Table, pl string, idx
 
 	if idx == len(sf.plNames) {
 
+		// same order as payoffSeqKey
 		otherMoves := make([]string, 0, len(sf.plNames)-1)
-		for seqPl, seq := range sequences {
+		for _, seqPl := range sf.plNames {
 			if seqPl != pl {
-				otherMoves = append(otherMoves, seq)
+				otherMoves = append(otherMoves, sequences[seqPl])
 			}
 		}
 
 		// base case...
-		if len(otherMoves) == 1 {
-			table.Append(otherMoves[0])
-		} else {
-			fmt.Println(strings.Join(otherMoves, ":"))
-			panic("not impl yet...")
-		}
-
 		key := strings.Join(otherMoves, ":")
+		table.Append(key)
 
 		for _, plSeq := range sf.plSeqs[pl] {
 			payoff := sf.plPayoffs[pl][plSeq][key]
@@ -2,6 +2,7 @@ package gametheory
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -135,8 +136,61 @@ func TestSequenceFormString(t *testing.T) {
 		t.Error(err)
 	}
 
-	sf := NewSequenceForm(&rootFactory)
+	sf, err := NewSequenceForm(&rootFactory)
+	if err != nil {
+		t.Fatal(err)
+	}
 	fmt.Println(sf.String())
 
-	sf.CreateLCP()
+	err = sf.CreateLCP()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSequenceFormImperfectRecall(t *testing.T) {
+	gameJSON := []byte(`{
+    "player": "A",
+    "iset": "A1",
+    "moves": [{
+      "name": "L",
+      "next": {
+        "player": "A",
+        "iset": "A2",
+        "moves": [{
+          "name": "l",
+          "outcome": [{ "player": "A", "payoff": 1 }]
+        }]
+      }
+    },{
+      "name": "R",
+      "next": {
+        "player": "A",
+        "iset": "A2",
+        "moves": [{
+          "name": "l",
+          "outcome": [{ "player": "A", "payoff": 0 }]
+        }]
+      }
+    }]
+  }`)
+
+	var rootFactory NodeFactory
+	err := json.Unmarshal(gameJSON, &rootFactory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewSequenceForm(&rootFactory)
+	if !errors.Is(err, ErrImperfectRecall) {
+		t.Fatalf("expected imperfect recall, got %v", err)
+	}
+
+	var recallErr *ImperfectRecallError
+	if !errors.As(err, &recallErr) {
+		t.Fatalf("expected an *ImperfectRecallError, got %T", err)
+	}
+	if recallErr.Player != "A" || recallErr.Iset != "A2" || recallErr.Seq != "R" || recallErr.Expected != "L" {
+		t.Errorf("unexpected details %+v", recallErr)
+	}
 }
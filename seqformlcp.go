@@ -2,6 +2,7 @@ package gametheory
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/megesdal/gametheory/lemke"
 	"github.com/megesdal/matrixprinter"
@@ -21,10 +22,17 @@ func (sf *SequenceForm) Lemke() {
 	// 5. Create Equilibrium and compute payoffs
 }
 
-func (sf *SequenceForm) CreateLCP() {
+// CreateLCP builds the sequence form LCP of a two player game and solves it.
+func (sf *SequenceForm) CreateLCP() error {
+	return sf.CreateLCPContext(context.Background())
+}
+
+// CreateLCPContext is CreateLCP but stops with a *lemke.CanceledError once
+// ctx is done.
+func (sf *SequenceForm) CreateLCPContext(ctx context.Context) error {
 
 	if len(sf.plNames) != 2 {
-		panic("Sequence Form LCP must have two and only two players")
+		return fmt.Errorf("%w: found %d", ErrPlayerCount, len(sf.plNames))
 	}
 
 	// preprocess priors here so that we can re-randomize the priors without having to reconstruct this object?
@@ -117,13 +125,19 @@ func (sf *SequenceForm) CreateLCP() {
 
 	d := sf.coveringVector(M, q)
 
-	z, err := lemke.Solve(lemke.NewLCP(M, q), d)
+	lcp, err := lemke.NewLCP(M, q)
+	if err != nil {
+		return err
+	}
+
+	z, err := lemke.SolveContext(ctx, lcp, d)
 	if err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println("SUCCESS...", z)
-		sf.parseLemkeSolution(z)
+		return err
 	}
+
+	fmt.Println("SUCCESS...", z)
+	sf.parseLemkeSolution(z)
+	return nil
 }
 
 func (sf *SequenceForm) coveringVector(M []*big.Rat, q []*big.Rat) []*big.Rat {